@@ -1,7 +1,18 @@
 package cmd
 
+// ConfigLoader reads configs.
+type ConfigLoader interface {
+	LoadFromFile(string) error
+}
+
 // ConfigSerializer reads and serializes JSON configs.
 type ConfigSerializer interface {
-	LoadFromFile(string) error
+	ConfigLoader
 	ToJSON() ([]byte, error)
 }
+
+// YAMLSerializer reads and serializes YAML configs.
+type YAMLSerializer interface {
+	ConfigLoader
+	ToYAML() ([]byte, error)
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -19,8 +21,26 @@ var (
 
 	// test flag for fmt command displays the proposed changes
 	fmtTest bool
+
+	// sort-exercises flag for fmt command orders the exercises in config.json
+	fmtSortExercises bool
+
+	// backup flag for fmt command keeps a .orig copy of each file it changes
+	fmtBackup bool
+
+	// stdin flag for fmt command formats a single file read from stdin
+	fmtStdin bool
+
+	// kind flag for fmt command is the kind of file read from stdin
+	fmtKind string
 )
 
+// fmtKinds are the kinds of file that fmt can read from stdin.
+var fmtKinds = map[string]func() ConfigSerializer{
+	"config":      func() ConfigSerializer { return &track.Config{} },
+	"maintainers": func() ConfigSerializer { return &track.MaintainerConfig{} },
+}
+
 // fmtCmd defines the fmt command
 var fmtCmd = &cobra.Command{
 	Use:   "fmt " + pathExample,
@@ -28,12 +48,38 @@ var fmtCmd = &cobra.Command{
 	Long: `The fmt command formats the track's configuration files.
 
 It ensures the following files have consistent JSON syntax and indentation:
-	config.json, maintainers.json
+	config.json, maintainers.json, exercises/*/.meta/config.json
+
+It also formats the exercises' metadata overrides, exercises/*/.meta/metadata.yml,
+with their keys in a canonical order and their strings double-quoted.
+Files with comments are skipped, as formatting would lose the comments.
 
 It also normalizes and alphabetizes the exercise topics in the config.json file.
+
+The keys are written in a canonical order. Keys that configlet does not know
+about are kept, after the known keys in alphabetical order.
+
+With --sort-exercises, the exercises in config.json are ordered with the core
+exercises first, then the side exercises grouped by the core exercise that
+unlocks them, then the remaining side exercises, and finally the deprecated
+exercises. Exercises otherwise keep their order.
+
+Changed files are replaced in a single step, keeping their file mode, so an
+interrupted run never leaves a partly written file. With --backup, the previous
+contents of each changed file are kept in a copy ending in .orig.
+
+With --stdin, a single config.json or maintainers.json, as given by --kind,
+is read from stdin and the formatted result is written to stdout.
 `,
-	Example: fmt.Sprintf("  %s fmt %s --verbose", binaryName, pathExample),
+	Example: fmt.Sprintf("  %s fmt %s --verbose\n  %s fmt --stdin --kind=config < config.json", binaryName, pathExample, binaryName),
 	Run: func(cmd *cobra.Command, args []string) {
+		if fmtStdin {
+			if err := runFmtStdin(os.Stdin, os.Stdout); err != nil {
+				ui.PrintError(err.Error())
+				os.Exit(1)
+			}
+			return
+		}
 
 		if diffFound, err := runFmt(args[0], args[0]); err != nil {
 			ui.PrintError(err.Error())
@@ -44,7 +90,40 @@ It also normalizes and alphabetizes the exercise topics in the config.json file.
 
 	},
 
-	Args: cobra.ExactArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if fmtStdin {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+}
+
+// runFmtStdin formats the file of the kind given by the kind flag that is read from r,
+// and writes the result to w.
+func runFmtStdin(r io.Reader, w io.Writer) error {
+	newCfg, ok := fmtKinds[fmtKind]
+	if !ok {
+		return fmt.Errorf("unknown kind %q, expected config or maintainers", fmtKind)
+	}
+
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	cfg := newCfg()
+	if err := json.Unmarshal(src, cfg); err != nil {
+		return track.NewJSONError("<stdin>", src, err)
+	}
+	if c, ok := cfg.(*track.Config); ok && fmtSortExercises {
+		c.SortExercises()
+	}
+
+	dst, err := cfg.ToJSON()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", dst)
+	return err
 }
 
 func runFmt(inDir, outDir string) (bool, error) {
@@ -59,7 +138,7 @@ func runFmt(inDir, outDir string) (bool, error) {
 	var fs = []struct {
 		inPath  string
 		outPath string
-		cfg     ConfigSerializer
+		cfg     ConfigLoader
 	}{
 		{
 			filepath.Join(inDir, "config.json"),
@@ -73,11 +152,36 @@ func runFmt(inDir, outDir string) (bool, error) {
 		},
 	}
 
+	exercises, err := exerciseConfigFiles(inDir)
+	if err != nil {
+		return false, err
+	}
+	for _, path := range exercises {
+		var cfg ConfigLoader = &track.ExerciseConfig{}
+		if filepath.Ext(path) == ".yml" {
+			cfg = &track.Metadata{}
+		}
+		fs = append(fs, struct {
+			inPath  string
+			outPath string
+			cfg     ConfigLoader
+		}{
+			filepath.Join(inDir, path),
+			filepath.Join(outDir, path),
+			cfg,
+		})
+	}
+
 	var changes string
 
 	errs := &multierror.Error{}
 	for _, f := range fs {
 		diff, err := formatFile(f.cfg, f.inPath, f.outPath)
+		if cerr, ok := err.(*track.CommentError); ok {
+			// The file is left as it is, rather than losing its comments.
+			ui.Print("not formatted:", cerr)
+			continue
+		}
 		if err != nil {
 			errs = multierror.Append(errs, err)
 			continue
@@ -104,7 +208,44 @@ func runFmt(inDir, outDir string) (bool, error) {
 	return diffFound, nil
 }
 
-func formatFile(cfg ConfigSerializer, inPath, outPath string) (string, error) {
+// exerciseConfigFiles returns the paths of the exercises' metadata.yml and
+// config.json files in the track, relative to the track directory.
+func exerciseConfigFiles(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(filepath.Join(dir, "exercises"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		for _, name := range []string{"metadata.yml", "config.json"} {
+			path := filepath.Join("exercises", info.Name(), ".meta", name)
+			if _, err := os.Stat(filepath.Join(dir, path)); err == nil {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// serialize returns the formatted contents of the config.
+func serialize(cfg ConfigLoader) ([]byte, error) {
+	switch c := cfg.(type) {
+	case ConfigSerializer:
+		return c.ToJSON()
+	case YAMLSerializer:
+		return c.ToYAML()
+	}
+	return nil, fmt.Errorf("cannot format %T", cfg)
+}
+
+func formatFile(cfg ConfigLoader, inPath, outPath string) (string, error) {
 	src, err := ioutil.ReadFile(inPath)
 	if err != nil {
 		return "", err
@@ -112,28 +253,43 @@ func formatFile(cfg ConfigSerializer, inPath, outPath string) (string, error) {
 	if err := cfg.LoadFromFile(inPath); err != nil {
 		return "", err
 	}
-	dst, err := cfg.ToJSON()
+	if c, ok := cfg.(*track.Config); ok && fmtSortExercises {
+		c.SortExercises()
+	}
+	dst, err := serialize(cfg)
 	if err != nil {
 		return "", err
 	}
 	dst = []byte(string(fmt.Sprintf("%s\n", dst)))
 
-	a := difflib.SplitLines(string(src))
-	b := difflib.SplitLines(string(dst))
-	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{A: a, B: b})
+	diff, err := diffLines(src, dst)
 	if err != nil {
 		return "", err
 	}
 	if diff != "" && !fmtTest {
-		if err := ioutil.WriteFile(outPath, dst, os.FileMode(0644)); err != nil {
+		if err := os.MkdirAll(filepath.Dir(outPath), os.ModePerm); err != nil {
+			return "", err
+		}
+		if err := writeFile(outPath, dst, fmtBackup); err != nil {
 			return "", err
 		}
 	}
 	return diff, nil
 }
 
+// diffLines returns the unified diff between the src and dst file contents.
+func diffLines(src, dst []byte) (string, error) {
+	a := difflib.SplitLines(string(src))
+	b := difflib.SplitLines(string(dst))
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{A: a, B: b})
+}
+
 func init() {
 	RootCmd.AddCommand(fmtCmd)
 	fmtCmd.Flags().BoolVarP(&fmtVerbose, "verbose", "v", false, "display the diff of the formatted changes.")
 	fmtCmd.Flags().BoolVarP(&fmtTest, "test", "t", false, "display the proposed changes, but do not make them.")
+	fmtCmd.Flags().BoolVar(&fmtStdin, "stdin", false, "format a single file read from stdin, and write it to stdout.")
+	fmtCmd.Flags().StringVar(&fmtKind, "kind", "config", "the kind of file read from stdin: config or maintainers.")
+	fmtCmd.Flags().BoolVar(&fmtBackup, "backup", false, "keep a copy of each changed file, ending in .orig.")
+	fmtCmd.Flags().BoolVar(&fmtSortExercises, "sort-exercises", false, "order the exercises in config.json by core, unlocked side, other side and deprecated exercises.")
 }
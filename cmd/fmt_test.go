@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/exercism/configlet/track"
+	"github.com/exercism/configlet/ui"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,6 +64,19 @@ func TestFmtCommand(t *testing.T) {
 	}
 	assert.Equal(t, maintainer, maintainerCfg)
 
+	for _, name := range []string{"metadata.yml", "config.json"} {
+		path := filepath.Join("exercises", "one", ".meta", name)
+		expected, err := ioutil.ReadFile(filepath.Join("..", "fixtures", "format", "formatted", path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := ioutil.ReadFile(filepath.Join(malformedDir, path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, string(expected), string(actual))
+	}
+
 	// It does not rewrite an incorrectly formatted file when the diff opton is true
 	unformattedDir, err := ioutil.TempDir("", "unformatted")
 	if err != nil {
@@ -94,3 +111,173 @@ func TestSemanticsOfMissingTopics(t *testing.T) {
 	_, err = os.Stat(filepath.Join(semanticsDir, "config.json"))
 	assert.True(t, os.IsNotExist(err))
 }
+
+func TestFmtKeepsUnknownKeys(t *testing.T) {
+	originalTest := fmtTest
+	originalVerbose := fmtVerbose
+	defer func() {
+		fmtTest = originalTest
+		fmtVerbose = originalVerbose
+	}()
+
+	// Unknown keys are kept, after the known keys in alphabetical order.
+	fmtTest = true
+	fmtVerbose = false
+	diffFound, err := runFmt("../fixtures/format/unknown-keys/", "../fixtures/format/unknown-keys/")
+	assert.NoError(t, err)
+	assert.False(t, diffFound)
+}
+
+func TestFmtSortExercises(t *testing.T) {
+	originalTest := fmtTest
+	originalVerbose := fmtVerbose
+	originalSort := fmtSortExercises
+	defer func() {
+		fmtTest = originalTest
+		fmtVerbose = originalVerbose
+		fmtSortExercises = originalSort
+	}()
+
+	dir, err := ioutil.TempDir("", "sort-exercises")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := `{"exercises": [
+		{"slug": "side", "unlocked_by": "core"},
+		{"slug": "old", "deprecated": true},
+		{"slug": "core", "core": true}
+	]}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(src), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+
+	fmtTest = false
+	fmtVerbose = false
+	fmtSortExercises = true
+	diffFound, _ := runFmt(dir, dir)
+	assert.True(t, diffFound)
+
+	cfg, err := track.NewConfig(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	slugs := []string{}
+	for _, exercise := range cfg.Exercises {
+		slugs = append(slugs, exercise.Slug)
+	}
+	assert.Equal(t, []string{"core", "side", "old"}, slugs)
+}
+
+func TestFmtSkipsMetadataWithComments(t *testing.T) {
+	originalTest := fmtTest
+	originalVerbose := fmtVerbose
+	originalOut := ui.Out
+	defer func() {
+		fmtTest = originalTest
+		fmtVerbose = originalVerbose
+		ui.Out = originalOut
+	}()
+
+	dir, err := ioutil.TempDir("", "comments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, path := range []string{"config.json", filepath.Join("config", "maintainers.json")} {
+		b, err := ioutil.ReadFile(filepath.Join("..", "fixtures", "format", "formatted", path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, path), b, os.FileMode(0644)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	meta := filepath.Join(dir, "exercises", "one", ".meta")
+	if err := os.MkdirAll(meta, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	src := "---\n# A comment.\nblurb: 'One.'\n"
+	if err := ioutil.WriteFile(filepath.Join(meta, "metadata.yml"), []byte(src), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	ui.Out = &out
+
+	fmtTest = false
+	fmtVerbose = false
+	diffFound, err := runFmt(dir, dir)
+	assert.NoError(t, err)
+	assert.False(t, diffFound)
+	assert.Contains(t, out.String(), "not formatted:")
+	assert.Contains(t, out.String(), "has a comment on line 2")
+
+	actual, err := ioutil.ReadFile(filepath.Join(meta, "metadata.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, src, string(actual))
+}
+
+func TestFmtStdin(t *testing.T) {
+	originalKind := fmtKind
+	defer func() { fmtKind = originalKind }()
+
+	tests := []struct {
+		kind      string
+		malformed string
+		formatted string
+	}{
+		{"config", "malformed/config.json", "formatted/config.json"},
+		{"maintainers", "malformed/config/maintainers.json", "formatted/config/maintainers.json"},
+	}
+
+	for _, tt := range tests {
+		src, err := os.Open(filepath.Join("..", "fixtures", "format", filepath.FromSlash(tt.malformed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer src.Close()
+		expected, err := ioutil.ReadFile(filepath.Join("..", "fixtures", "format", filepath.FromSlash(tt.formatted)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fmtKind = tt.kind
+		var out bytes.Buffer
+		assert.NoError(t, runFmtStdin(src, &out))
+		assert.Equal(t, string(expected), out.String())
+	}
+}
+
+func TestFmtStdinErrors(t *testing.T) {
+	originalKind := fmtKind
+	defer func() { fmtKind = originalKind }()
+
+	tests := []struct {
+		kind string
+		src  string
+		err  string
+	}{
+		{"config", "{\n  \"active\": tru\n}", "<stdin>:2:16: active: invalid character"},
+		{"config", "{\n  \"language\": 3\n}", "<stdin>:2:15: language: expected a string, found a number"},
+		{"config", "{", "<stdin>:1:1: unexpected end of JSON input"},
+		{"unknown", "{}", `unknown kind "unknown"`},
+	}
+
+	for _, tt := range tests {
+		fmtKind = tt.kind
+		var out bytes.Buffer
+		err := runFmtStdin(strings.NewReader(tt.src), &out)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), tt.err)
+		}
+		assert.Equal(t, "", out.String())
+	}
+}
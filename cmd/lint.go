@@ -1,12 +1,14 @@
 package cmd
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/exercism/configlet/track"
 	"github.com/exercism/configlet/ui"
@@ -21,6 +23,14 @@ var (
 	// trackID flag allows the user to specify the ID of the track,
 	// for example if it is different to the local directory name
 	trackID string
+	// listRules flag prints the available lint rules instead of linting.
+	listRules bool
+	// enabledRules flag restricts linting to the rules with the given IDs.
+	enabledRules []string
+	// disabledRules flag skips the rules with the given IDs.
+	disabledRules []string
+	// strict flag makes lint fail on warnings as well as errors.
+	strict bool
 )
 
 // lintCmd defines the lint command.
@@ -33,117 +43,211 @@ It ensures the following files are valid JSON:
 	config.json, maintainers.json
 
 It also checks that the exercises defined in the config.json file are complete.
+
+Each check is a rule with a stable ID. Use --list-rules to see them all,
+and --enable or --disable to choose which ones are run. Rules that are off
+by default report warnings when they are enabled.
+
+A .configlet.yml file in the track root may change the severity of a rule
+(error, warning or off), and suppress findings for specific exercises:
+
+	rules:
+	  missing-readme: warning
+	suppressions:
+	  - slug: hello-world
+	    rule: invalid-unlocked-by
+	    reason: Being moved to the new track structure.
+
+Warnings are reported, but only make lint fail when --strict is given.
+The exit status is 0 if the track passed, 1 if there were errors,
+and 2 if there were only warnings and --strict was given.
+
+Several tracks can be linted at once, either by giving each of their paths,
+or by giving --all and the directories that contain the tracks. The tracks
+are linted concurrently, followed by a summary of the findings for each
+track, and the exit status is that of the worst track.
+
+Some findings can be fixed mechanically. With --fix they are fixed in
+config.json, which is formatted as the fmt command would. Add --dry-run
+to see the changes without making them.
 `,
 	Example: lintExampleText(),
 	Run:     runLint,
-	Args:    cobra.ExactArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if listRules {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 }
 
 func lintExampleText() string {
 	cmds := []string{
 		"%[1]s lint %[2]s",
 		"%[1]s lint %[2]s --no-http",
+		"%[1]s lint %[2]s --tracks-dir=<path/to/tracks>",
 		"%[1]s lint %[2]s --track-id=<track id>",
+		"%[1]s lint %[2]s --format=sarif",
+		"%[1]s lint %[2]s --disable=missing-readme,missing-test-suite",
+		"%[1]s lint %[2]s --strict",
+		"%[1]s lint %[2]s --fix --dry-run",
+		"%[1]s lint --all <path/to/tracks> --jobs=4",
+		"%[1]s lint --list-rules",
 	}
 	s := "  " + strings.Join(cmds, "\n\n  ")
 	return fmt.Sprintf(s, binaryName, pathExample)
 }
 
 func runLint(cmd *cobra.Command, args []string) {
-	var hasErrors bool
-	for _, arg := range args {
-		if failed := lintTrack(arg); failed {
-			hasErrors = true
+	if !isValidLintFormat(lintFormat) {
+		ui.PrintError(fmt.Sprintf("unknown format %q, expected one of: %s", lintFormat, strings.Join(lintFormats, ", ")))
+		os.Exit(1)
+	}
+	if httpRetries < 0 {
+		ui.PrintError(fmt.Sprintf("--http-retries must not be negative, found %d", httpRetries))
+		os.Exit(1)
+	}
+	for _, id := range append(enabledRules, disabledRules...) {
+		if _, ok := findRule(id); !ok {
+			ui.PrintError(fmt.Sprintf("unknown lint rule %q, see --list-rules", id))
+			os.Exit(1)
 		}
 	}
-	if hasErrors {
+
+	if listRules {
+		printRules()
+		return
+	}
+
+	paths, err := trackPaths(args)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	if trackID != "" && len(paths) > 1 {
+		ui.PrintError("--track-id can only be used when linting a single track")
+		os.Exit(1)
+	}
+	if lintDryRun && !lintFix {
+		ui.PrintError("--dry-run can only be used with --fix")
+		os.Exit(1)
+	}
+
+	status := lintTracks(paths)
+	switch {
+	case status == lintFailed:
 		os.Exit(1)
+	case status == lintWarned && strict:
+		os.Exit(2)
 	}
 }
 
-func lintTrack(path string) bool {
+func lintTrack(path string) lintStatus {
+	findings, diff := lintFindings(path)
+	printDryRun(diff)
+	return report(findings)
+}
+
+// lintFindings checks the track, and applies the fixes when --fix is given.
+// With --dry-run, it also returns the diff of the changes the fixes would make.
+func lintFindings(path string) ([]Finding, string) {
+	findings := checkTrack(path)
+
+	if !lintFix {
+		return findings, ""
+	}
+	fixed, diff, err := fixTrack(path, findings)
+	if err != nil {
+		findings = append(findings, loadFailure(path, err))
+	} else if len(fixed) > 0 {
+		// Check again, as the fixes may have resolved or caused other findings.
+		findings = append(fixed, checkTrack(path)...)
+	}
+	return findings, diff
+}
+
+// checkTrack runs the enabled rules against the track at path.
+func checkTrack(path string) []Finding {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		ui.PrintError("path not found:", path)
-		return true
+		return []Finding{loadFailure(path, fmt.Errorf("path not found: %s", path))}
 	}
 
-	t, err := track.New(path)
+	t, err := track.New(path, track.FollowSymlinks(followSymlinks))
 	if err != nil {
-		ui.PrintError(err.Error())
-		return true
+		return []Finding{loadFailure(path, err)}
 	}
 
 	if trackID != "" {
 		t.ID = trackID
 	}
 
-	configErrors := []struct {
-		check func(track.Track) []string
-		msg   string
-	}{
-		{
-			check: missingImplementations,
-			msg:   "An exercise with slug '%v' is referenced in config.json, but no implementation was found.",
-		},
-		{
-			check: missingMetadata,
-			msg:   "An implementation for '%v' was found, but config.json does not reference this exercise.",
-		},
-		{
-			check: missingReadme,
-			msg:   "The implementation for '%v' is missing a README.",
-		},
-		{
-			check: missingSolution,
-			msg:   "The implementation for '%v' is missing an example solution.",
-		},
-		{
-			check: missingTestSuite,
-			msg:   "The implementation for '%v' is missing a test suite.",
-		},
-		{
-			check: missingUUID,
-			msg:   "The exercise '%v' was found in config.json, but does not have a UUID.",
-		},
-		{
-			check: foregoneViolations,
-			msg:   "An implementation for '%v' was found, but config.json specifies that it should be foregone (not implemented).",
-		},
-		{
-			check: duplicateSlugs,
-			msg:   "The exercise '%v' was found in multiple (conflicting) categories in config.json.",
-		},
-		{
-			check: duplicateUUID,
-			msg:   "The following UUID occurs multiple times. Each exercise UUID must be unique.\n%v",
-		},
-		{
-			check: duplicateTrackUUID,
-			msg:   "The following UUID was found in multiple Exercism tracks. Each exercise UUID must be unique across tracks.\n%v",
-		},
-		{
-			check: lockedCoreViolation,
-			msg:   "The exercise '%v' is marked as core and unlocked by another exercise. A core exercise should not be unlocked by another.",
-		},
-		{
-			check: unlockedByValidExercise,
-			msg:   "The exercise '%v' is being unlocked by a non-core exercise. Non-core exercises can only be unlocked by core exercises.",
-		},
+	src, err := ioutil.ReadFile(filepath.Join(path, "config.json"))
+	if err != nil {
+		return []Finding{loadFailure(path, err)}
 	}
 
-	var hasErrors bool
-	for _, configError := range configErrors {
-		failedItems := configError.check(t)
-
-		if len(failedItems) > 0 {
-			hasErrors = true
-			for _, item := range failedItems {
-				ui.Print(fmt.Sprintf(configError.msg, item))
+	cfg, err := newLintConfig(path)
+	if err != nil {
+		return []Finding{loadFailure(path, err)}
+	}
 
+	findings := []Finding{}
+	for _, rule := range rules {
+		severity := cfg.severity(rule)
+		if !isRuleEnabled(rule.ID()) {
+			continue
+		}
+		if severity == SeverityOff {
+			// Rules that are off can still be run by enabling them explicitly.
+			if !isRuleExplicitlyEnabled(rule.ID()) {
+				continue
+			}
+			severity = SeverityWarning
+		}
+		for _, f := range rule.Check(t) {
+			if cfg.isSuppressed(f) {
+				continue
 			}
+			// Findings take the severity the rule is configured or enabled with,
+			// unless the rule gave them a severity of their own, such as a warning
+			// that a service the rule depends on could not be used.
+			if f.Severity == "" || f.Severity == rule.Severity() {
+				f.Severity = severity
+			}
+			f.locate(path, src)
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// isRuleEnabled checks the --enable and --disable flags to see if a rule should run.
+func isRuleEnabled(id string) bool {
+	for _, disabled := range disabledRules {
+		if disabled == id {
+			return false
+		}
+	}
+	return len(enabledRules) == 0 || isRuleExplicitlyEnabled(id)
+}
+
+// isRuleExplicitlyEnabled checks if the rule was named in the --enable flag.
+func isRuleExplicitlyEnabled(id string) bool {
+	for _, enabled := range enabledRules {
+		if enabled == id {
+			return true
 		}
 	}
-	return hasErrors
+	return false
+}
+
+// printRules lists the registered rules with their default severity.
+func printRules() {
+	w := tabwriter.NewWriter(ui.Out, 0, 0, 2, ' ', 0)
+	for _, rule := range rules {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", rule.ID(), rule.Severity(), rule.Description())
+	}
+	w.Flush()
 }
 
 func missingImplementations(t track.Track) []string {
@@ -222,6 +326,10 @@ func missingReadme(t track.Track) []string {
 	for _, slug := range t.Config.ForegoneSlugs {
 		readmes[slug] = true
 	}
+	// Deprecated exercises are no longer maintained, so they need no README.
+	for slug := range deprecatedSlugs(t) {
+		readmes[slug] = true
+	}
 
 	slugs := []string{}
 	for slug, ok := range readmes {
@@ -318,9 +426,11 @@ func duplicateUUID(t track.Track) []string {
 	return uuids
 }
 
-func duplicateTrackUUID(t track.Track) []string {
+// duplicateTrackUUID asks Exercism's UUID validation service
+// which of the track's UUIDs are already used by other tracks.
+func duplicateTrackUUID(t track.Track) ([]string, error) {
 	if noHTTP {
-		return []string{}
+		return []string{}, nil
 	}
 
 	// Build up set of uuids to validate.
@@ -342,28 +452,27 @@ func duplicateTrackUUID(t track.Track) []string {
 
 	body, err := json.Marshal(payload)
 	if err != nil {
-		ui.PrintError(err.Error())
-		os.Exit(1)
+		return nil, err
 	}
 
-	resp, err := http.Post(UUIDValidationURL, "application/json", bytes.NewBuffer(body))
+	resp, err := postWithRetries(uuidServiceURL(), body)
 	if err != nil {
-		ui.PrintError(err.Error())
-		os.Exit(1)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusConflict {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return []string{}, nil
+	case http.StatusConflict:
 		result := struct{ UUIDs []string }{}
 		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			ui.PrintError(err.Error())
-			os.Exit(1)
+			return nil, fmt.Errorf("invalid response from %s -- %s", resp.Request.URL, err.Error())
 		}
-
-		return result.UUIDs
+		return result.UUIDs, nil
+	default:
+		return nil, fmt.Errorf("unexpected response from %s -- %s", resp.Request.URL, resp.Status)
 	}
-
-	return []string{}
 }
 
 func lockedCoreViolation(t track.Track) []string {
@@ -400,8 +509,176 @@ func unlockedByValidExercise(t track.Track) []string {
 	return slugs
 }
 
+func missingCoreExercises(t track.Track) []string {
+	for _, exercise := range t.Config.Exercises {
+		if exercise.IsCore && !exercise.IsDeprecated {
+			return []string{}
+		}
+	}
+	return []string{t.ID}
+}
+
+func missingUnlocks(t track.Track) []string {
+	for _, exercise := range t.Config.Exercises {
+		if exercise.UnlockedBy != nil && !exercise.IsDeprecated {
+			return []string{}
+		}
+	}
+	return []string{t.ID}
+}
+
+func missingBonusExercises(t track.Track) []string {
+	for _, exercise := range t.Config.Exercises {
+		if !exercise.IsCore && exercise.UnlockedBy == nil && !exercise.IsDeprecated {
+			return []string{}
+		}
+	}
+	return []string{t.ID}
+}
+
 func init() {
+	RegisterRule(checkRule{
+		id:          "missing-implementation",
+		description: "Exercises in config.json must have an implementation.",
+		severity:    SeverityError,
+		check:       missingImplementations,
+		msg:         "An exercise with slug '%v' is referenced in config.json, but no implementation was found.",
+		subject:     configSlug,
+	})
+	RegisterRule(fixableRule{
+		checkRule: checkRule{
+			id:          "missing-metadata",
+			description: "Implemented exercises must be referenced in config.json.",
+			severity:    SeverityError,
+			check:       missingMetadata,
+			msg:         "An implementation for '%v' was found, but config.json does not reference this exercise.",
+			subject:     exerciseSlug,
+		},
+		fix: fixMissingMetadata,
+	})
+	RegisterRule(checkRule{
+		id:          "missing-readme",
+		description: "Implemented exercises must have a README.",
+		severity:    SeverityError,
+		check:       missingReadme,
+		msg:         "The implementation for '%v' is missing a README.",
+		subject:     exerciseSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "missing-solution",
+		description: "Implemented exercises must have an example solution.",
+		severity:    SeverityError,
+		check:       missingSolution,
+		msg:         "The implementation for '%v' is missing an example solution.",
+		subject:     exerciseSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "missing-test-suite",
+		description: "Implemented exercises must have a test suite.",
+		severity:    SeverityError,
+		check:       missingTestSuite,
+		msg:         "The implementation for '%v' is missing a test suite.",
+		subject:     exerciseSlug,
+	})
+	RegisterRule(fixableRule{
+		checkRule: checkRule{
+			id:          "missing-uuid",
+			description: "Exercises in config.json must have a UUID.",
+			severity:    SeverityError,
+			check:       missingUUID,
+			msg:         "The exercise '%v' was found in config.json, but does not have a UUID.",
+			subject:     configSlug,
+		},
+		fix: fixMissingUUID,
+	})
+	RegisterRule(fixableRule{
+		checkRule: checkRule{
+			id:          "foregone-violation",
+			description: "Foregone exercises must not be implemented.",
+			severity:    SeverityError,
+			check:       foregoneViolations,
+			msg:         "An implementation for '%v' was found, but config.json specifies that it should be foregone (not implemented).",
+			subject:     exerciseSlug,
+		},
+		fix: fixForegoneViolation,
+	})
+	RegisterRule(checkRule{
+		id:          "duplicate-slug",
+		description: "Slugs must appear only once across exercises, foregone and deprecated.",
+		severity:    SeverityError,
+		check:       duplicateSlugs,
+		msg:         "The exercise '%v' was found in multiple (conflicting) categories in config.json.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "duplicate-uuid",
+		description: "Exercise UUIDs must be unique within the track.",
+		severity:    SeverityError,
+		check:       duplicateUUID,
+		msg:         "The following UUID occurs multiple times. Each exercise UUID must be unique.\n%v",
+		subject:     configUUID,
+	})
+	RegisterRule(trackUUIDRule{
+		checkRule: checkRule{
+			id:          "duplicate-track-uuid",
+			description: "Exercise UUIDs must be unique across all Exercism tracks.",
+			severity:    SeverityError,
+			msg:         "The following UUID was found in multiple Exercism tracks. Each exercise UUID must be unique across tracks.\n%v",
+			subject:     configUUID,
+		},
+	})
+	RegisterRule(fixableRule{
+		checkRule: checkRule{
+			id:          "locked-core",
+			description: "Core exercises must not be unlocked by another exercise.",
+			severity:    SeverityError,
+			check:       lockedCoreViolation,
+			msg:         "The exercise '%v' is marked as core and unlocked by another exercise. A core exercise should not be unlocked by another.",
+			subject:     configSlug,
+		},
+		fix: fixLockedCore,
+	})
+	RegisterRule(checkRule{
+		id:          "invalid-unlocked-by",
+		description: "Exercises can only be unlocked by core exercises.",
+		severity:    SeverityError,
+		check:       unlockedByValidExercise,
+		msg:         "The exercise '%v' is being unlocked by a non-core exercise. Non-core exercises can only be unlocked by core exercises.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "missing-core-exercises",
+		description: "Tracks should have core exercises.",
+		severity:    SeverityWarning,
+		check:       missingCoreExercises,
+		msg:         "The track '%v' does not have any core exercises.",
+		subject:     trackConfig,
+	})
+	RegisterRule(checkRule{
+		id:          "missing-unlocks",
+		description: "Tracks should have exercises that are unlocked by core exercises.",
+		severity:    SeverityWarning,
+		check:       missingUnlocks,
+		msg:         "The track '%v' does not have any exercises that are unlocked by a core exercise.",
+		subject:     trackConfig,
+	})
+	RegisterRule(checkRule{
+		id:          "missing-bonus-exercises",
+		description: "Tracks should have bonus exercises, which are neither core nor unlocked by another exercise.",
+		severity:    SeverityWarning,
+		check:       missingBonusExercises,
+		msg:         "The track '%v' does not have any bonus exercises.",
+		subject:     trackConfig,
+	})
+
 	RootCmd.AddCommand(lintCmd)
 	lintCmd.Flags().BoolVar(&noHTTP, "no-http", false, "Disable remote HTTP-based linting.")
 	lintCmd.Flags().StringVar(&trackID, "track-id", "", "Specify the track ID (defaults to local directory name).")
+	lintCmd.Flags().StringVar(&lintFormat, "format", "text", "Output format: "+strings.Join(lintFormats, ", ")+".")
+	lintCmd.Flags().BoolVar(&listRules, "list-rules", false, "List the available lint rules.")
+	lintCmd.Flags().StringSliceVar(&enabledRules, "enable", nil, "Only run the lint rules with these IDs.")
+	lintCmd.Flags().StringSliceVar(&disabledRules, "disable", nil, "Do not run the lint rules with these IDs.")
+	lintCmd.Flags().BoolVar(&strict, "strict", false, "Fail on warnings as well as errors.")
+	lintCmd.Flags().BoolVar(&lintFix, "fix", false, "Fix the findings that can be fixed in config.json.")
+	lintCmd.Flags().BoolVar(&lintDryRun, "dry-run", false, "Display the changes --fix would make, without making them. Requires --fix.")
 }
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/exercism/configlet/track"
+)
+
+// ambiguousFiles returns the files in the exercise that are matched by both
+// the solution pattern and the test pattern.
+func ambiguousFiles(t track.Track, exercise track.Exercise) []string {
+	solutions := map[string]bool{}
+	for _, path := range exercise.SolutionPaths {
+		solutions[path] = true
+	}
+
+	files := []string{}
+	for _, path := range exercise.TestSuitePaths {
+		if solutions[path] {
+			files = append(files, filepath.ToSlash(path))
+		}
+	}
+	return files
+}
+
+func multipleSolutions(t track.Track) []string {
+	slugs := []string{}
+	for _, exercise := range t.Exercises {
+		if len(exercise.SolutionPaths) > 1 {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func init() {
+	RegisterRule(fileRule{
+		id:          "ambiguous-file",
+		description: "Files should not match both the solution pattern and the test pattern.",
+		severity:    SeverityWarning,
+		check:       ambiguousFiles,
+		msg:         "The file '%s' in the exercise '%s' matches both the solution_pattern and the test_pattern.",
+	})
+	RegisterRule(checkRule{
+		id:          "multiple-solutions",
+		description: "Exercises should have a single file matching the solution pattern.",
+		severity:    SeverityOff,
+		check:       multipleSolutions,
+		msg:         "The implementation for '%v' has more than one file matching the solution_pattern.",
+		subject:     exerciseSlug,
+	})
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmbiguousMatches(t *testing.T) {
+	track := track.Track{
+		Exercises: []track.Exercise{
+			{
+				Slug:           "apple",
+				SolutionPaths:  []string{"example.ext"},
+				TestSuitePaths: []string{"apple_test.ext"},
+			},
+			{
+				Slug:           "banana",
+				SolutionPaths:  []string{"example.ext", "example_test.ext"},
+				TestSuitePaths: []string{"banana_test.ext", "example_test.ext"},
+			},
+		},
+	}
+
+	assert.Empty(t, ambiguousFiles(track, track.Exercises[0]))
+	assert.Equal(t, []string{"example_test.ext"}, ambiguousFiles(track, track.Exercises[1]))
+	assert.Equal(t, []string{"banana"}, multipleSolutions(track))
+
+	rule, ok := findRule("ambiguous-file")
+	if !ok {
+		t.Fatal("ambiguous-file rule is not registered")
+	}
+	findings := rule.Check(track)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, "exercises/banana/example_test.ext", findings[0].Path)
+		assert.Equal(t, "The file 'example_test.ext' in the exercise 'banana' matches both the solution_pattern and the test_pattern.", findings[0].Message)
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// lintConfigFilename is the name of the lint configuration file in the track root.
+const lintConfigFilename = ".configlet.yml"
+
+// lintConfig is a track's own configuration of the lint rules.
+//
+// Rules maps rule IDs to the severity their findings should have,
+// and Suppressions silences the findings for specific exercises.
+type lintConfig struct {
+	Rules        map[string]Severity `yaml:"rules"`
+	Suppressions []suppression       `yaml:"suppressions"`
+}
+
+// suppression silences findings about an exercise.
+// If Rule is empty, the findings of all rules are silenced.
+// Every suppression must give a reason.
+type suppression struct {
+	Rule   string `yaml:"rule"`
+	Slug   string `yaml:"slug"`
+	Reason string `yaml:"reason"`
+}
+
+// newLintConfig reads the lint configuration in the track root, if present.
+func newLintConfig(root string) (lintConfig, error) {
+	cfg := lintConfig{}
+
+	path := filepath.Join(root, lintConfigFilename)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cfg, nil
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.UnmarshalStrict(bytes, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config %s -- %s", path, err.Error())
+	}
+	if err := cfg.validate(); err != nil {
+		return cfg, fmt.Errorf("invalid config %s -- %s", path, err.Error())
+	}
+	return cfg, nil
+}
+
+func (cfg lintConfig) validate() error {
+	for id, severity := range cfg.Rules {
+		if _, ok := findRule(id); !ok {
+			return fmt.Errorf("unknown rule %q", id)
+		}
+		if !isValidSeverity(severity) {
+			return fmt.Errorf("rule %q has unknown severity %q, expected error, warning or off", id, severity)
+		}
+	}
+
+	for i, s := range cfg.Suppressions {
+		if s.Slug == "" {
+			return fmt.Errorf("suppression %d does not have a slug", i+1)
+		}
+		if s.Reason == "" {
+			return fmt.Errorf("suppression for %q does not give a reason", s.Slug)
+		}
+		if _, ok := findRule(s.Rule); s.Rule != "" && !ok {
+			return fmt.Errorf("suppression for %q has unknown rule %q", s.Slug, s.Rule)
+		}
+	}
+	return nil
+}
+
+// severity returns the severity configured for a rule,
+// falling back to the rule's default.
+func (cfg lintConfig) severity(r Rule) Severity {
+	if severity, ok := cfg.Rules[r.ID()]; ok {
+		return severity
+	}
+	return r.Severity()
+}
+
+// isSuppressed checks if the finding has been suppressed.
+func (cfg lintConfig) isSuppressed(f Finding) bool {
+	for _, s := range cfg.Suppressions {
+		if s.Slug == f.Slug && (s.Rule == "" || s.Rule == f.RuleID) {
+			return true
+		}
+	}
+	return false
+}
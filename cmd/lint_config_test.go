@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/configlet/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintConfiguredTrack(t *testing.T) {
+	originalOut := ui.Out
+	defer func() { ui.Out = originalOut }()
+
+	var out bytes.Buffer
+	ui.Out = &out
+
+	status := lintTrack(filepath.FromSlash("../fixtures/lint/configured-track"))
+	assert.Equal(t, lintWarned, status, "should not fail when all findings are warnings or suppressed.")
+	expected := "-> warning: The implementation for 'carbon' is missing a test suite.\n" +
+		"-> warning: The key 'repository' in config.json is not known to configlet, so it is not checked.\n" +
+		"-> warning: The key 'slug' in config.json is not known to configlet, so it is not checked.\n"
+	assert.Equal(t, expected, out.String())
+}
+
+func TestNewLintConfig(t *testing.T) {
+	tests := []struct {
+		desc   string
+		config string
+		valid  bool
+	}{
+		{
+			desc:   "should accept severities and suppressions.",
+			config: "rules:\n  missing-readme: off\n  missing-uuid: warning\nsuppressions:\n  - slug: apple\n    reason: because\n",
+			valid:  true,
+		},
+		{
+			desc:   "should reject unknown rules.",
+			config: "rules:\n  no-such-rule: warning\n",
+		},
+		{
+			desc:   "should reject unknown severities.",
+			config: "rules:\n  missing-readme: fatal\n",
+		},
+		{
+			desc:   "should reject suppressions without a reason.",
+			config: "suppressions:\n  - slug: apple\n    rule: missing-readme\n",
+		},
+		{
+			desc:   "should reject suppressions without a slug.",
+			config: "suppressions:\n  - rule: missing-readme\n    reason: because\n",
+		},
+		{
+			desc:   "should reject suppressions of unknown rules.",
+			config: "suppressions:\n  - slug: apple\n    rule: no-such-rule\n    reason: because\n",
+		},
+		{
+			desc:   "should reject unknown keys.",
+			config: "severities:\n  missing-readme: warning\n",
+		},
+	}
+
+	for _, tt := range tests {
+		dir, err := ioutil.TempDir("", "lint-config")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		if err := ioutil.WriteFile(filepath.Join(dir, lintConfigFilename), []byte(tt.config), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = newLintConfig(dir)
+		assert.Equal(t, tt.valid, err == nil, tt.desc)
+	}
+}
+
+func TestLintConfigSuppressions(t *testing.T) {
+	cfg := lintConfig{
+		Suppressions: []suppression{
+			{Slug: "apple", Rule: "missing-readme", Reason: "because"},
+			{Slug: "banana", Reason: "because"},
+		},
+	}
+
+	assert.True(t, cfg.isSuppressed(Finding{RuleID: "missing-readme", Slug: "apple"}))
+	assert.False(t, cfg.isSuppressed(Finding{RuleID: "missing-uuid", Slug: "apple"}))
+	assert.True(t, cfg.isSuppressed(Finding{RuleID: "missing-uuid", Slug: "banana"}))
+	assert.False(t, cfg.isSuppressed(Finding{RuleID: "missing-readme", Slug: "cherry"}))
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"github.com/exercism/configlet/track"
+)
+
+// inconsistentDeprecations returns the slugs of the exercises that are in
+// the legacy deprecated list, but whose entry in exercises is not flagged as
+// deprecated. Flagging them and removing them from the list resolves this.
+// An exercise that is flagged but not listed is already migrated, and an
+// exercise in the list without an entry is reported by legacy-deprecated.
+func inconsistentDeprecations(t track.Track) []string {
+	listed := map[string]bool{}
+	for _, slug := range t.Config.DeprecatedSlugs {
+		listed[slug] = true
+	}
+
+	slugs := []string{}
+	for _, exercise := range t.Config.Exercises {
+		if listed[exercise.Slug] && !exercise.IsDeprecated {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+// legacyDeprecations returns the slugs in the legacy deprecated list
+// that do not have an entry in exercises.
+func legacyDeprecations(t track.Track) []string {
+	entries := map[string]bool{}
+	for _, exercise := range t.Config.Exercises {
+		entries[exercise.Slug] = true
+	}
+
+	slugs := []string{}
+	for _, slug := range t.Config.DeprecatedSlugs {
+		if !entries[slug] {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+func deprecatedCore(t track.Track) []string {
+	deprecated := deprecatedSlugs(t)
+
+	slugs := []string{}
+	for _, exercise := range t.Config.Exercises {
+		if exercise.IsCore && deprecated[exercise.Slug] {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func deprecatedReadmes(t track.Track) []string {
+	deprecated := deprecatedSlugs(t)
+
+	slugs := []string{}
+	for _, exercise := range t.Exercises {
+		if deprecated[exercise.Slug] && exercise.HasReadme() {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+// migrateDeprecatedSlug flags the exercise as deprecated, adding an entry
+// for it if there is none, and removes it from the legacy deprecated list.
+// It reports whether the config was changed.
+func migrateDeprecatedSlug(cfg *track.Config, slug string) bool {
+	changed := false
+	for i, s := range cfg.DeprecatedSlugs {
+		if s == slug {
+			cfg.DeprecatedSlugs = append(cfg.DeprecatedSlugs[:i], cfg.DeprecatedSlugs[i+1:]...)
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return false
+	}
+
+	for i, exercise := range cfg.Exercises {
+		if exercise.Slug == slug {
+			cfg.Exercises[i].IsDeprecated = true
+			return true
+		}
+	}
+	stub := stubExercise(slug)
+	stub.IsDeprecated = true
+	cfg.Exercises = append(cfg.Exercises, stub)
+	return true
+}
+
+// migrateDeprecated converts the legacy deprecated list into deprecated flags,
+// and returns the slugs of the exercises that were migrated.
+func migrateDeprecated(cfg *track.Config) []string {
+	slugs := append([]string{}, cfg.DeprecatedSlugs...)
+	for _, slug := range slugs {
+		migrateDeprecatedSlug(cfg, slug)
+	}
+	return slugs
+}
+
+func fixLegacyDeprecation(cfg *track.Config, f Finding) bool {
+	return migrateDeprecatedSlug(cfg, f.Slug)
+}
+
+func init() {
+	RegisterRule(fixableRule{
+		checkRule: checkRule{
+			id:          "inconsistent-deprecated",
+			description: "Exercises in the legacy deprecated list must be flagged as deprecated in config.json.",
+			severity:    SeverityError,
+			check:       inconsistentDeprecations,
+			msg:         "The exercise '%v' is in the legacy deprecated list in config.json, but is not flagged as deprecated.",
+			subject:     configSlug,
+		},
+		fix: fixLegacyDeprecation,
+	})
+	RegisterRule(fixableRule{
+		checkRule: checkRule{
+			id:          "legacy-deprecated",
+			description: "Deprecated exercises should be flagged as deprecated, rather than listed in the legacy deprecated list.",
+			severity:    SeverityWarning,
+			check:       legacyDeprecations,
+			msg:         "The exercise '%v' is in the legacy deprecated list in config.json. Run 'configlet migrate-deprecated' to flag it as deprecated instead.",
+			subject:     configSlug,
+		},
+		fix: fixLegacyDeprecation,
+	})
+	RegisterRule(checkRule{
+		id:          "deprecated-core",
+		description: "Deprecated exercises must not be core exercises.",
+		severity:    SeverityError,
+		check:       deprecatedCore,
+		msg:         "The exercise '%v' is deprecated, but is still marked as core.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "deprecated-readme",
+		description: "Deprecated exercises should not keep a README, as it is no longer maintained.",
+		severity:    SeverityWarning,
+		check:       deprecatedReadmes,
+		msg:         "The exercise '%v' is deprecated, but its directory still contains a README.",
+		subject:     exerciseSlug,
+	})
+}
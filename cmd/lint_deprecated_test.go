@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/exercism/configlet/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecatedChecks(t *testing.T) {
+	deprecatedTrack, err := track.New(filepath.FromSlash("../fixtures/lint/deprecated-track"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc     string
+		check    func(track.Track) []string
+		expected []string
+	}{
+		{
+			desc:     "should report listed exercises that are not flagged, but not flagged exercises that are not listed.",
+			check:    inconsistentDeprecations,
+			expected: []string{"boron"},
+		},
+		{
+			desc:     "should report exercises that are only in the legacy deprecated list.",
+			check:    legacyDeprecations,
+			expected: []string{"dysprosium"},
+		},
+		{
+			desc:     "should report deprecated core exercises.",
+			check:    deprecatedCore,
+			expected: []string{"carbon"},
+		},
+		{
+			desc:     "should report deprecated exercises with a README.",
+			check:    deprecatedReadmes,
+			expected: []string{"boron"},
+		},
+		{
+			desc:     "should not report missing READMEs for deprecated exercises.",
+			check:    missingReadme,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		slugs := tt.check(deprecatedTrack)
+		sort.Strings(slugs)
+		assert.Equal(t, tt.expected, slugs, tt.desc)
+	}
+}
+
+func TestMigrateDeprecated(t *testing.T) {
+	originalDryRun := migrateDryRun
+	originalOut := ui.Out
+	defer func() {
+		migrateDryRun = originalDryRun
+		ui.Out = originalOut
+	}()
+
+	dir := copyDir(t, filepath.FromSlash("../fixtures/lint/deprecated-track"))
+	defer os.RemoveAll(dir)
+	configPath := filepath.Join(dir, "config.json")
+
+	original, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	ui.Out = &out
+
+	// A dry run displays the changes, but does not make them.
+	migrateDryRun = true
+	assert.NoError(t, runMigrateDeprecated(dir))
+	assert.Contains(t, out.String(), "-  \"deprecated\": [")
+	assert.Contains(t, out.String(), "+      \"slug\": \"dysprosium\",")
+	assert.Contains(t, out.String(), "+      \"topics\": [],")
+
+	unchanged, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(original), string(unchanged))
+
+	out.Reset()
+	migrateDryRun = false
+	assert.NoError(t, runMigrateDeprecated(dir))
+	assert.Equal(t, "-> flagged as deprecated: boron\n-> flagged as deprecated: dysprosium\n", out.String())
+
+	cfg, err := track.NewConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, cfg.DeprecatedSlugs)
+	if assert.Equal(t, 4, len(cfg.Exercises)) {
+		assert.False(t, cfg.Exercises[0].IsDeprecated)
+		assert.True(t, cfg.Exercises[1].IsDeprecated)
+		assert.True(t, cfg.Exercises[2].IsDeprecated)
+		assert.Equal(t, "dysprosium", cfg.Exercises[3].Slug)
+		assert.True(t, cfg.Exercises[3].IsDeprecated)
+		assert.NotEmpty(t, cfg.Exercises[3].UUID)
+		assert.Equal(t, []string{}, cfg.Exercises[3].Topics)
+	}
+
+	migrated, err := track.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, inconsistentDeprecations(migrated))
+	assert.Empty(t, legacyDeprecations(migrated))
+	assert.Empty(t, duplicateSlugs(migrated))
+
+	// Migrating again changes nothing.
+	out.Reset()
+	assert.NoError(t, runMigrateDeprecated(dir))
+	assert.Contains(t, out.String(), "no deprecated exercises to migrate")
+}
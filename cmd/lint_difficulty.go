@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"github.com/exercism/configlet/track"
+)
+
+const (
+	minDifficulty = 1
+	maxDifficulty = 10
+)
+
+// maxDifficultyDrop flag is how much easier a core exercise may be
+// than the core exercise before it.
+var maxDifficultyDrop int
+
+func invalidDifficulty(t track.Track) []string {
+	slugs := []string{}
+	for _, exercise := range activeExercises(t) {
+		if exercise.Difficulty < minDifficulty || exercise.Difficulty > maxDifficulty {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func easierThanUnlocker(t track.Track) []string {
+	exercises := activeExercises(t)
+
+	core := map[string]int{}
+	for _, exercise := range exercises {
+		if exercise.IsCore {
+			core[exercise.Slug] = exercise.Difficulty
+		}
+	}
+
+	slugs := []string{}
+	for _, exercise := range exercises {
+		if exercise.UnlockedBy == nil {
+			continue
+		}
+		if difficulty, ok := core[*exercise.UnlockedBy]; ok && exercise.Difficulty < difficulty {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func coreDifficultyRegressions(t track.Track) []string {
+	slugs := []string{}
+
+	var previous *track.ExerciseMetadata
+	for _, exercise := range activeExercises(t) {
+		if !exercise.IsCore {
+			continue
+		}
+		if previous != nil && previous.Difficulty-exercise.Difficulty > maxDifficultyDrop {
+			slugs = append(slugs, exercise.Slug)
+		}
+		e := exercise
+		previous = &e
+	}
+	return slugs
+}
+
+// minSkewedExercises is the number of exercises a track needs
+// before the distribution of their difficulties is checked.
+const minSkewedExercises = 5
+
+func skewedDifficulties(t track.Track) []string {
+	exercises := activeExercises(t)
+	if len(exercises) < minSkewedExercises {
+		return []string{}
+	}
+
+	counts := map[int]int{}
+	for _, exercise := range exercises {
+		counts[exercise.Difficulty]++
+	}
+	for _, count := range counts {
+		if 2*count > len(exercises) {
+			return []string{t.ID}
+		}
+	}
+	return []string{}
+}
+
+func init() {
+	RegisterRule(checkRule{
+		id:          "invalid-difficulty",
+		description: "Exercise difficulties must be between 1 and 10.",
+		severity:    SeverityError,
+		check:       invalidDifficulty,
+		msg:         "The exercise '%v' has a difficulty outside of the range 1 to 10.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "easier-than-unlocker",
+		description: "Exercises should not be easier than the core exercise that unlocks them.",
+		severity:    SeverityWarning,
+		check:       easierThanUnlocker,
+		msg:         "The exercise '%v' is easier than the core exercise that unlocks it.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "core-difficulty-regression",
+		description: "Core exercises should not be much easier than the core exercise before them.",
+		severity:    SeverityWarning,
+		check:       coreDifficultyRegressions,
+		msg:         "The core exercise '%v' is much easier than the core exercise before it.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "skewed-difficulty",
+		description: "Most exercises should not have the same difficulty.",
+		severity:    SeverityOff,
+		check:       skewedDifficulties,
+		msg:         "More than half of the exercises in the track '%v' have the same difficulty.",
+		subject:     trackConfig,
+	})
+
+	lintCmd.Flags().IntVar(&maxDifficultyDrop, "max-difficulty-drop", 3, "How much easier a core exercise may be than the one before it.")
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidDifficulty(t *testing.T) {
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", Difficulty: 0},
+				{Slug: "banana", Difficulty: 1},
+				{Slug: "cherry", Difficulty: 10},
+				{Slug: "durian", Difficulty: 11},
+				{Slug: "eggplant", Difficulty: 0, IsDeprecated: true},
+			},
+		},
+	}
+
+	slugs := invalidDifficulty(track)
+	sort.Strings(slugs)
+	assert.Equal(t, []string{"apple", "durian"}, slugs)
+}
+
+func TestEasierThanUnlocker(t *testing.T) {
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", IsCore: true, Difficulty: 3},
+				{Slug: "banana", UnlockedBy: &apple, Difficulty: 2},
+				{Slug: "cherry", UnlockedBy: &apple, Difficulty: 3},
+				{Slug: "durian", UnlockedBy: &unknown, Difficulty: 1},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"banana"}, easierThanUnlocker(track))
+}
+
+func TestCoreDifficultyRegressions(t *testing.T) {
+	originalMaxDifficultyDrop := maxDifficultyDrop
+	maxDifficultyDrop = 3
+	defer func() { maxDifficultyDrop = originalMaxDifficultyDrop }()
+
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", IsCore: true, Difficulty: 2},
+				{Slug: "banana", IsCore: true, Difficulty: 6},
+				{Slug: "cherry", Difficulty: 1},
+				{Slug: "durian", IsCore: true, Difficulty: 3},
+				{Slug: "eggplant", IsCore: true, Difficulty: 9},
+				{Slug: "fig", IsCore: true, Difficulty: 5},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"fig"}, coreDifficultyRegressions(track))
+}
+
+func TestSkewedDifficulties(t *testing.T) {
+	tests := []struct {
+		desc         string
+		difficulties []int
+		skewed       bool
+	}{
+		{
+			desc:         "should not check tracks with few exercises.",
+			difficulties: []int{1, 1, 1, 1},
+		},
+		{
+			desc:         "should report when most exercises have the same difficulty.",
+			difficulties: []int{1, 1, 1, 2, 3},
+			skewed:       true,
+		},
+		{
+			desc:         "should not report an even distribution.",
+			difficulties: []int{1, 1, 2, 2, 3, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		exercises := []track.ExerciseMetadata{}
+		for _, difficulty := range tt.difficulties {
+			exercises = append(exercises, track.ExerciseMetadata{Difficulty: difficulty})
+		}
+		track := track.Track{ID: "fruit", Config: track.Config{Exercises: exercises}}
+		assert.Equal(t, tt.skewed, len(skewedDifficulties(track)) > 0, tt.desc)
+	}
+}
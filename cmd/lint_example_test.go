@@ -20,6 +20,14 @@ func ExampleLint() {
 	// -> The implementation for 'two' is missing a test suite.
 	// -> The exercise 'one' was found in config.json, but does not have a UUID.
 	// -> An implementation for 'zero' was found, but config.json specifies that it should be foregone (not implemented).
+	// -> warning: The track 'numbers' does not have any core exercises.
+	// -> warning: The track 'numbers' does not have any exercises that are unlocked by a core exercise.
+	// -> warning: The exercise 'two' is deprecated, but its directory still contains a README.
+	// -> warning: The key 'repository' in config.json is not known to configlet, so it is not checked.
+	// -> warning: The key 'slug' in config.json is not known to configlet, so it is not checked.
+	// -> warning: The exercise 'bajillion' does not have any topics.
+	// -> warning: The exercise 'one' does not have any topics.
+	// -> warning: The exercise 'three' does not have any topics.
 }
 
 func ExampleLintMaintainers() {
@@ -30,5 +38,7 @@ func ExampleLintMaintainers() {
 
 	lintTrack(filepath.FromSlash("../fixtures/broken-maintainers"))
 	// Output:
-	// -> invalid config ../fixtures/broken-maintainers/config/maintainers.json -- invalid character '}' looking for beginning of object key string
+	// -> invalid config ../fixtures/broken-maintainers/config/maintainers.json:6:5: maintainers[0]: invalid character '}' looking for beginning of object key string
+	//         }
+	//         ^
 }
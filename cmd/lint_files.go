@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/exercism/configlet/track"
+)
+
+// followSymlinks flag follows symbolic links to directories in exercise directories, instead of skipping them.
+var followSymlinks bool
+
+// unreadableFileRule reports the files and directories in the exercises
+// that could not be read, such as broken symbolic links.
+type unreadableFileRule struct{}
+
+func (unreadableFileRule) ID() string { return "unreadable-file" }
+
+func (unreadableFileRule) Description() string {
+	return "The files in exercise directories must be readable, and symbolic links must not be broken."
+}
+
+func (unreadableFileRule) Severity() Severity { return SeverityError }
+
+func (r unreadableFileRule) Check(t track.Track) []Finding {
+	findings := []Finding{}
+	for _, exercise := range t.Exercises {
+		for _, fileErr := range exercise.FileErrors {
+			f := newFinding(t, exerciseSlug, exercise.Slug)
+			f.RuleID = r.ID()
+			f.Message = fmt.Sprintf("The file '%s' in the exercise '%s' could not be read: %s", fileErr.Path, exercise.Slug, fileErr.Err)
+			f.Path = path.Join(f.Path, fileErr.Path)
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+func init() {
+	RegisterRule(unreadableFileRule{})
+
+	lintCmd.Flags().BoolVar(&followSymlinks, "follow-symlinks", false, "Follow symbolic links to directories in exercise directories, instead of skipping them.")
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnreadableFiles(t *testing.T) {
+	track := track.Track{
+		Exercises: []track.Exercise{
+			{Slug: "apple"},
+			{
+				Slug: "banana",
+				FileErrors: []track.FileError{
+					{Path: "broken.ext", Err: errors.New("no such file or directory")},
+				},
+			},
+		},
+	}
+
+	findings := unreadableFileRule{}.Check(track)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, "banana", findings[0].Slug)
+		assert.Equal(t, "exercises/banana/broken.ext", findings[0].Path)
+		assert.Equal(t, "The file 'broken.ext' in the exercise 'banana' could not be read: no such file or directory", findings[0].Message)
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/exercism/configlet/track"
+	"github.com/exercism/configlet/ui"
+	"github.com/google/uuid"
+)
+
+var (
+	// lintFix flag applies the fixes for findings that can be repaired mechanically.
+	lintFix bool
+	// lintDryRun flag displays the changes that --fix would make, without making them.
+	lintDryRun bool
+)
+
+// Fixer is implemented by rules that can repair their findings in config.json.
+type Fixer interface {
+	// Fix changes the config to resolve the finding, and reports whether it did.
+	Fix(cfg *track.Config, f Finding) bool
+}
+
+// fixableRule is a checkRule with a fix for its findings.
+type fixableRule struct {
+	checkRule
+	fix func(*track.Config, Finding) bool
+}
+
+func (r fixableRule) Fix(cfg *track.Config, f Finding) bool {
+	return r.fix(cfg, f)
+}
+
+// fixTrack applies the available fixes to the track's config.json,
+// and returns the findings that were fixed. With --dry-run the diff is
+// returned instead, config.json is left unchanged, and nothing is fixed.
+func fixTrack(path string, findings []Finding) ([]Finding, string, error) {
+	configPath := filepath.Join(path, "config.json")
+
+	cfg := track.Config{}
+	if err := cfg.LoadFromFile(configPath); err != nil {
+		return nil, "", err
+	}
+
+	fixed := []Finding{}
+	for _, f := range findings {
+		rule, ok := findRule(f.RuleID)
+		if !ok {
+			continue
+		}
+		if fixer, ok := rule.(Fixer); ok && fixer.Fix(&cfg, f) {
+			f.Fixed = true
+			fixed = append(fixed, f)
+		}
+	}
+	if len(fixed) == 0 {
+		return fixed, "", nil
+	}
+
+	src, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, "", err
+	}
+	dst, err := cfg.ToJSON()
+	if err != nil {
+		return nil, "", err
+	}
+	dst = []byte(fmt.Sprintf("%s\n", dst))
+
+	if lintDryRun {
+		diff, err := diffLines(src, dst)
+		if err != nil {
+			return nil, "", err
+		}
+		return []Finding{}, fmt.Sprintf("%s\n\n%s", configPath, diff), nil
+	}
+
+	if err := writeFile(configPath, dst, false); err != nil {
+		return nil, "", err
+	}
+	return fixed, "", nil
+}
+
+// printDryRun displays the diff of the changes --fix would make. It is written
+// to stderr for the machine-readable formats, to keep their output valid.
+func printDryRun(diff string) {
+	if diff == "" {
+		return
+	}
+	if lintFormat == "text" {
+		ui.Print(diff)
+	} else {
+		ui.PrintError(diff)
+	}
+}
+
+func fixMissingUUID(cfg *track.Config, f Finding) bool {
+	for i, exercise := range cfg.Exercises {
+		if exercise.Slug == f.Slug && exercise.UUID == "" {
+			cfg.Exercises[i].UUID = uuid.New().String()
+			return true
+		}
+	}
+	return false
+}
+
+func fixMissingMetadata(cfg *track.Config, f Finding) bool {
+	for _, exercise := range cfg.Exercises {
+		if exercise.Slug == f.Slug {
+			return false
+		}
+	}
+	cfg.Exercises = append(cfg.Exercises, stubExercise(f.Slug))
+	return true
+}
+
+// stubExercise returns a new entry for the exercise in config.json,
+// with a new UUID and an empty list of topics.
+func stubExercise(slug string) track.ExerciseMetadata {
+	return track.ExerciseMetadata{
+		Slug:       slug,
+		UUID:       uuid.New().String(),
+		Difficulty: 1,
+		Topics:     []string{},
+	}
+}
+
+func fixForegoneViolation(cfg *track.Config, f Finding) bool {
+	for i, slug := range cfg.ForegoneSlugs {
+		if slug == f.Slug {
+			cfg.ForegoneSlugs = append(cfg.ForegoneSlugs[:i], cfg.ForegoneSlugs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func fixLockedCore(cfg *track.Config, f Finding) bool {
+	for i, exercise := range cfg.Exercises {
+		if exercise.Slug == f.Slug && exercise.IsCore && exercise.UnlockedBy != nil {
+			cfg.Exercises[i].UnlockedBy = nil
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/exercism/configlet/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+// copyDir copies the fixture at src into a temporary directory,
+// so that commands which modify a track can be tested.
+func copyDir(t *testing.T, src string) string {
+	dst, err := ioutil.TempDir("", filepath.Base(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(target, b, info.Mode())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dst
+}
+
+func TestLintFix(t *testing.T) {
+	originalNoHTTP := noHTTP
+	originalFix := lintFix
+	originalDryRun := lintDryRun
+	originalOut := ui.Out
+	noHTTP = true
+	defer func() {
+		noHTTP = originalNoHTTP
+		lintFix = originalFix
+		lintDryRun = originalDryRun
+		ui.Out = originalOut
+	}()
+
+	dir := copyDir(t, filepath.FromSlash("../fixtures/lint/fixable-track"))
+	defer os.RemoveAll(dir)
+
+	original, err := ioutil.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	ui.Out = &out
+
+	// A dry run displays the changes, but does not make them.
+	lintFix = true
+	lintDryRun = true
+	status := lintTrack(dir)
+	assert.Equal(t, lintFailed, status)
+	assert.Contains(t, out.String(), "-  \"foregone\": [")
+	assert.Contains(t, out.String(), "+      \"slug\": \"dysprosium\",")
+
+	unchanged, err := ioutil.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(original), string(unchanged))
+
+	// Fixing resolves all of the errors. The new stub exercise does not have
+	// any topics, which is only a warning.
+	out.Reset()
+	lintDryRun = false
+	status = lintTrack(dir)
+	assert.Equal(t, lintWarned, status)
+	assert.Contains(t, out.String(), "-> fixed: The exercise 'boron' was found in config.json, but does not have a UUID.")
+
+	cfg, err := track.NewConfig(filepath.Join(dir, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, cfg.ForegoneSlugs)
+	assert.Nil(t, cfg.Exercises[0].UnlockedBy)
+	assert.NotEmpty(t, cfg.Exercises[1].UUID)
+	if assert.Equal(t, 4, len(cfg.Exercises)) {
+		assert.Equal(t, "dysprosium", cfg.Exercises[3].Slug)
+		assert.NotEmpty(t, cfg.Exercises[3].UUID)
+		assert.Equal(t, []string{}, cfg.Exercises[3].Topics, "should write the stub's topics as an empty list, not null.")
+	}
+
+	// There is nothing left to fix.
+	out.Reset()
+	lintFix = false
+	assert.Equal(t, lintWarned, lintTrack(dir))
+	assert.Equal(t, "-> warning: The exercise 'dysprosium' does not have any topics.\n", out.String())
+}
+
+func TestLintFixDryRunSeveralTracks(t *testing.T) {
+	originalNoHTTP := noHTTP
+	originalFix := lintFix
+	originalDryRun := lintDryRun
+	originalJobs := lintJobs
+	originalOut := ui.Out
+	noHTTP = true
+	lintFix = true
+	lintDryRun = true
+	lintJobs = 2
+	defer func() {
+		noHTTP = originalNoHTTP
+		lintFix = originalFix
+		lintDryRun = originalDryRun
+		lintJobs = originalJobs
+		ui.Out = originalOut
+	}()
+
+	paths := []string{
+		copyDir(t, filepath.FromSlash("../fixtures/lint/fixable-track")),
+		copyDir(t, filepath.FromSlash("../fixtures/lint/fixable-track")),
+	}
+	for _, path := range paths {
+		defer os.RemoveAll(path)
+	}
+
+	var out bytes.Buffer
+	ui.Out = &out
+	lintTracks(paths)
+
+	// Each diff is displayed under the header of its track.
+	output := out.String()
+	first := strings.Index(output, "-> "+paths[0]+"\n")
+	second := strings.Index(output, "-> "+paths[1]+"\n")
+	if assert.True(t, first >= 0 && second > first, "should display the tracks in order.") {
+		assert.Contains(t, output[first:second], filepath.Join(paths[0], "config.json"))
+		assert.NotContains(t, output[first:second], filepath.Join(paths[1], "config.json"))
+		assert.Contains(t, output[second:], filepath.Join(paths[1], "config.json"))
+	}
+}
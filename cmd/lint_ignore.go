@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/exercism/configlet/track"
+)
+
+// ignoredFiles returns the files in the exercise that are matched by the
+// ignore pattern. Files in .meta are never delivered to students,
+// so they are not included.
+func ignoredFiles(t track.Track, exercise track.Exercise) []string {
+	files := []string{}
+	for _, file := range matching(t.Config.IgnorePattern, exercise.Files) {
+		if !strings.HasPrefix(file, ".meta/") {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// matching returns the files that are matched by the pattern.
+func matching(pattern string, files []string) []string {
+	matches := []string{}
+	rgx, err := regexp.Compile(pattern)
+	if pattern == "" || err != nil {
+		return matches
+	}
+	for _, file := range files {
+		if rgx.MatchString(file) {
+			matches = append(matches, file)
+		}
+	}
+	return matches
+}
+
+// ignoredNonSolutions returns the ignored files that are neither example
+// solutions nor test suites. Ignored test suites are reported separately.
+// The example solutions and test suites are the ones found when the exercise
+// was loaded, so that lint agrees with how configlet itself finds them.
+func ignoredNonSolutions(t track.Track, exercise track.Exercise) []string {
+	expected := map[string]bool{}
+	for _, paths := range [][]string{exercise.SolutionPaths, exercise.TestSuitePaths} {
+		for _, path := range paths {
+			expected[filepath.ToSlash(path)] = true
+		}
+	}
+
+	files := []string{}
+	for _, file := range ignoredFiles(t, exercise) {
+		if !expected[file] {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func ignoredTestSuites(t track.Track, exercise track.Exercise) []string {
+	tests := map[string]bool{}
+	for _, path := range exercise.TestSuitePaths {
+		tests[filepath.ToSlash(path)] = true
+	}
+
+	files := []string{}
+	for _, file := range ignoredFiles(t, exercise) {
+		if tests[file] {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func init() {
+	RegisterRule(fileRule{
+		id:          "ignored-file",
+		description: "Only example solutions should match the ignore pattern, as matching files are not delivered to students.",
+		severity:    SeverityWarning,
+		check:       ignoredNonSolutions,
+		msg:         "The file '%s' in the exercise '%s' matches the ignore pattern, so it is not delivered to students, but it is not an example solution.",
+	})
+	RegisterRule(fileRule{
+		id:          "ignored-test-suite",
+		description: "Test suites must not match the ignore pattern, as matching files are not delivered to students.",
+		severity:    SeverityError,
+		check:       ignoredTestSuites,
+		msg:         "The test file '%s' in the exercise '%s' matches the ignore pattern, so it is not delivered to students.",
+	})
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoredFiles(t *testing.T) {
+	track := track.Track{
+		Config: track.Config{
+			PatternGroup: track.PatternGroup{
+				IgnorePattern:   "[Ee]xample|helper|reference",
+				SolutionPattern: "[Ee]xample",
+				TestPattern:     "(?i)test",
+			},
+		},
+		Exercises: []track.Exercise{
+			{
+				Slug:           "apple",
+				Files:          []string{"README.md", "apple.ext", "apple_test.ext", "example.ext", ".meta/helper.ext"},
+				SolutionPaths:  []string{"example.ext"},
+				TestSuitePaths: []string{"apple_test.ext"},
+			},
+			{
+				Slug:           "banana",
+				Files:          []string{"README.md", "banana.ext", "Example.ext", "helper.ext", "example_test.ext"},
+				SolutionPaths:  []string{"Example.ext", "example_test.ext"},
+				TestSuitePaths: []string{"example_test.ext"},
+			},
+			{
+				// The solution pattern matched the full path of the file when the exercise was loaded.
+				Slug:           "cherry",
+				Files:          []string{"cherry.ext", "cherry_test.ext", "reference.ext"},
+				SolutionPaths:  []string{"reference.ext"},
+				TestSuitePaths: []string{"cherry_test.ext"},
+			},
+		},
+	}
+
+	assert.Empty(t, ignoredNonSolutions(track, track.Exercises[0]), "should not report example solutions or files in .meta.")
+	assert.Empty(t, ignoredTestSuites(track, track.Exercises[0]))
+
+	assert.Equal(t, []string{"helper.ext"}, ignoredNonSolutions(track, track.Exercises[1]))
+	assert.Equal(t, []string{"example_test.ext"}, ignoredTestSuites(track, track.Exercises[1]))
+
+	assert.Empty(t, ignoredNonSolutions(track, track.Exercises[2]), "should treat the files found as example solutions as expected.")
+
+	rule, ok := findRule("ignored-test-suite")
+	if !ok {
+		t.Fatal("ignored-test-suite rule is not registered")
+	}
+	findings := rule.Check(track)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, "banana", findings[0].Slug)
+		assert.Equal(t, "exercises/banana/example_test.ext", findings[0].Path)
+		assert.Equal(t, SeverityError, findings[0].Severity)
+	}
+
+	track.Config.IgnorePattern = ""
+	assert.Empty(t, ignoredNonSolutions(track, track.Exercises[1]), "should not ignore anything without an ignore pattern.")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/exercism/configlet/track"
+)
+
+// missingListedFiles returns the files listed in the exercise's
+// .meta/config.json that do not exist.
+func missingListedFiles(t track.Track, exercise track.Exercise) []string {
+	files := []string{}
+	if exercise.Config == nil {
+		return files
+	}
+
+	exists := map[string]bool{}
+	for _, file := range exercise.Files {
+		exists[file] = true
+	}
+	for _, file := range exercise.Config.Files.All() {
+		if !exists[file] {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// invalidExerciseConfigRule reports the exercises whose .meta/config.json
+// could not be read. The rest of the track is still checked, using the
+// patterns for those exercises.
+type invalidExerciseConfigRule struct{}
+
+func (invalidExerciseConfigRule) ID() string { return "invalid-exercise-config" }
+
+func (invalidExerciseConfigRule) Description() string {
+	return "An exercise's .meta/config.json must be valid JSON with the expected structure."
+}
+
+func (invalidExerciseConfigRule) Severity() Severity { return SeverityError }
+
+func (r invalidExerciseConfigRule) Check(t track.Track) []Finding {
+	findings := []Finding{}
+	for _, exercise := range t.Exercises {
+		if exercise.ConfigError == nil {
+			continue
+		}
+		f := newFinding(t, exerciseSlug, exercise.Slug)
+		f.RuleID = r.ID()
+		f.Message = fmt.Sprintf("The .meta/config.json of the exercise '%s' could not be read, so the patterns were used instead: %s", exercise.Slug, exercise.ConfigError)
+		f.Path = path.Join(f.Path, ".meta", "config.json")
+		var jsonErr *track.JSONError
+		if errors.As(exercise.ConfigError, &jsonErr) {
+			f.Line, f.Column = jsonErr.Line, jsonErr.Column
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+func init() {
+	RegisterRule(invalidExerciseConfigRule{})
+	RegisterRule(fileRule{
+		id:          "missing-listed-file",
+		description: "The files listed in an exercise's .meta/config.json must exist.",
+		severity:    SeverityError,
+		check:       missingListedFiles,
+		msg:         "The file '%s' is listed in the .meta/config.json of the exercise '%s', but does not exist.",
+	})
+}
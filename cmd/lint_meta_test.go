@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMissingListedFiles(t *testing.T) {
+	ex, err := track.NewExercise(filepath.FromSlash("../fixtures/meta-exercise"), track.PatternGroup{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	track := track.Track{
+		Exercises: []track.Exercise{
+			ex,
+			{Slug: "apple", Files: []string{"example.ext"}},
+		},
+	}
+
+	assert.Equal(t, []string{"src/helper.ext"}, missingListedFiles(track, track.Exercises[0]))
+	assert.Empty(t, missingListedFiles(track, track.Exercises[1]), "should not report exercises without .meta/config.json.")
+
+	rule, ok := findRule("missing-listed-file")
+	if !ok {
+		t.Fatal("missing-listed-file rule is not registered")
+	}
+	findings := rule.Check(track)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, "meta-exercise", findings[0].Slug)
+		assert.Equal(t, "exercises/meta-exercise/src/helper.ext", findings[0].Path)
+	}
+}
+
+func TestInvalidExerciseConfig(t *testing.T) {
+	dir := copyDir(t, filepath.FromSlash("../fixtures/lint/valid-track"))
+	defer os.RemoveAll(dir)
+
+	meta := filepath.Join(dir, "exercises", "aluminum", ".meta")
+	if err := os.MkdirAll(meta, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(meta, "config.json"), []byte("{\n  \"files\": []\n}\n"), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The rest of the track is still loaded and checked.
+	valid, err := track.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEmpty(t, valid.Exercises)
+
+	findings := invalidExerciseConfigRule{}.Check(valid)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, "aluminum", findings[0].Slug)
+		assert.Equal(t, "exercises/aluminum/.meta/config.json", findings[0].Path)
+		assert.Equal(t, 2, findings[0].Line)
+		assert.Equal(t, 12, findings[0].Column)
+		assert.Contains(t, findings[0].Message, "The .meta/config.json of the exercise 'aluminum' could not be read")
+	}
+	assert.Empty(t, invalidExerciseConfigRule{}.Check(track.Track{Exercises: []track.Exercise{{Slug: "apple"}}}))
+}
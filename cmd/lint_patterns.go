@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/exercism/configlet/track"
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// patternMatchRule reports solution and test patterns that match no files,
+// or more than one file, in most of the exercises. Such a pattern is likely
+// to be too strict or too loose.
+type patternMatchRule struct{}
+
+func (patternMatchRule) ID() string { return "imprecise-pattern" }
+
+func (patternMatchRule) Description() string {
+	return "The solution and test patterns should match exactly one file in most exercises."
+}
+
+func (patternMatchRule) Severity() Severity { return SeverityWarning }
+
+func (r patternMatchRule) Check(t track.Track) []Finding {
+	skipped := deprecatedSlugs(t)
+	for _, slug := range t.Config.ForegoneSlugs {
+		skipped[slug] = true
+	}
+	exercises := []track.Exercise{}
+	for _, exercise := range t.Exercises {
+		// Exercises that list their files in .meta/config.json do not use the patterns.
+		if !skipped[exercise.Slug] && exercise.Config == nil {
+			exercises = append(exercises, exercise)
+		}
+	}
+
+	// The files found by each pattern when the exercises were loaded.
+	patterns := []struct {
+		key     string
+		pattern string
+		paths   func(track.Exercise) []string
+	}{
+		{"solution_pattern", t.Config.SolutionPattern, func(e track.Exercise) []string { return e.SolutionPaths }},
+		{"test_pattern", t.Config.TestPattern, func(e track.Exercise) []string { return e.TestSuitePaths }},
+	}
+
+	findings := []Finding{}
+	for _, p := range patterns {
+		if p.pattern == "" {
+			continue
+		}
+
+		var none, several int
+		for _, exercise := range exercises {
+			switch n := len(p.paths(exercise)); {
+			case n == 0:
+				none++
+			case n > 1:
+				several++
+			}
+		}
+
+		var msg string
+		switch {
+		case 2*none > len(exercises):
+			msg = "The %s '%s' does not match any files in %d of the %d exercises."
+			msg = fmt.Sprintf(msg, p.key, p.pattern, none, len(exercises))
+		case 2*several > len(exercises):
+			msg = "The %s '%s' matches more than one file in %d of the %d exercises."
+			msg = fmt.Sprintf(msg, p.key, p.pattern, several, len(exercises))
+		default:
+			continue
+		}
+
+		f := newFinding(t, trackConfig, t.ID)
+		f.RuleID = r.ID()
+		f.Message = msg
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// ignorePatternRule reports an ignore pattern that is not a valid Go regular
+// expression. It does not stop the track from loading, as the Exercism website
+// accepts other expressions, but the files it ignores cannot be checked.
+type ignorePatternRule struct{}
+
+func (ignorePatternRule) ID() string { return "unsupported-ignore-pattern" }
+
+func (ignorePatternRule) Description() string {
+	return "The ignore pattern should be a valid Go regular expression, so that the files it ignores can be checked."
+}
+
+func (ignorePatternRule) Severity() Severity { return SeverityWarning }
+
+func (r ignorePatternRule) Check(t track.Track) []Finding {
+	pg := track.PatternGroup{IgnorePattern: t.Config.IgnorePattern}
+	errs, ok := pg.Validate().(*multierror.Error)
+	if !ok {
+		return []Finding{}
+	}
+
+	findings := []Finding{}
+	for _, err := range errs.Errors {
+		f := newFinding(t, trackConfig, t.ID)
+		f.RuleID = r.ID()
+		f.Message = fmt.Sprintf("The files ignored by the ignore_pattern cannot be checked, as the %s", err)
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+func init() {
+	RegisterRule(patternMatchRule{})
+	RegisterRule(ignorePatternRule{})
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImprecisePatterns(t *testing.T) {
+	exerciseConfig := &track.ExerciseConfig{}
+	track := track.Track{
+		Config: track.Config{
+			PatternGroup: track.PatternGroup{
+				SolutionPattern: "[Ee]xample",
+				TestPattern:     "(?i)test",
+			},
+			ForegoneSlugs: []string{"durian"},
+		},
+		Exercises: []track.Exercise{
+			{Slug: "apple", TestSuitePaths: []string{"apple_test.ext", "test_helper.ext"}},
+			{Slug: "banana", SolutionPaths: []string{"example.ext"}, TestSuitePaths: []string{"banana_test.ext", "test_helper.ext"}},
+			{Slug: "cherry", TestSuitePaths: []string{"cherry_test.ext", "test_helper.ext"}},
+			{Slug: "durian"},
+		},
+	}
+
+	findings := patternMatchRule{}.Check(track)
+	if assert.Equal(t, 2, len(findings)) {
+		assert.Equal(t, "The solution_pattern '[Ee]xample' does not match any files in 2 of the 3 exercises.", findings[0].Message)
+		assert.Equal(t, "The test_pattern '(?i)test' matches more than one file in 3 of the 3 exercises.", findings[1].Message)
+		assert.Equal(t, "config.json", findings[0].Path)
+	}
+
+	// Exercises that list their files do not use the patterns.
+	listed := track
+	listed.Exercises = nil
+	for _, exercise := range track.Exercises {
+		exercise.Config = exerciseConfig
+		listed.Exercises = append(listed.Exercises, exercise)
+	}
+	assert.Empty(t, patternMatchRule{}.Check(listed))
+
+	// The patterns are judged by the files found when the exercises were loaded.
+	track.Config.SolutionPattern = "[Ee]xample|\\.meta/solution"
+	track.Config.TestPattern = "_test\\.ext$"
+	for i := range track.Exercises[:3] {
+		track.Exercises[i].TestSuitePaths = track.Exercises[i].TestSuitePaths[:1]
+	}
+	track.Exercises[0].SolutionPaths = []string{".meta/solution.ext"}
+	track.Exercises[2].SolutionPaths = []string{"src/Example.ext"}
+	assert.Empty(t, patternMatchRule{}.Check(track))
+}
+
+func TestUnsupportedIgnorePattern(t *testing.T) {
+	track := track.Track{
+		Config: track.Config{
+			PatternGroup: track.PatternGroup{
+				IgnorePattern: "example(?!.*test)",
+			},
+		},
+	}
+
+	findings := ignorePatternRule{}.Check(track)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Contains(t, findings[0].Message, `ignore_pattern "example(?!.*test)" is not a valid regular expression at column 8`)
+	}
+
+	track.Config.IgnorePattern = "[Ee]xample"
+	assert.Empty(t, ignorePatternRule{}.Check(track))
+}
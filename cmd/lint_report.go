@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/exercism/configlet/track"
+	"github.com/exercism/configlet/ui"
+)
+
+// lintFormats are the output formats supported by the lint command.
+var lintFormats = []string{"text", "json", "sarif"}
+
+// lintFormat flag selects how lint findings are written.
+var lintFormat string
+
+// loadFailureRuleID identifies findings for tracks that could not be loaded at all.
+const loadFailureRuleID = "invalid-track"
+
+// Severity describes how serious a lint finding is.
+type Severity string
+
+const (
+	// SeverityError marks a finding that causes lint to fail.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a finding that is reported, but does not cause lint to fail.
+	SeverityWarning Severity = "warning"
+	// SeverityOff marks a rule that should not be run.
+	SeverityOff Severity = "off"
+)
+
+func isValidSeverity(s Severity) bool {
+	return s == SeverityError || s == SeverityWarning || s == SeverityOff
+}
+
+// Finding is a single problem that lint found in a track.
+// Path is the file or directory the finding refers to, and Line and Column
+// point into that file when the location could be determined.
+// Fixed findings have been repaired by lint, and no longer count as problems.
+type Finding struct {
+	RuleID   string   `json:"rule_id"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Slug     string   `json:"slug,omitempty"`
+	UUID     string   `json:"uuid,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Fixed    bool     `json:"fixed,omitempty"`
+}
+
+// lintSubject describes what the items returned by a check refer to,
+// which determines where the resulting findings are located.
+type lintSubject int
+
+const (
+	// configSlug items are exercise slugs referenced in config.json.
+	configSlug lintSubject = iota
+	// exerciseSlug items are the slugs of exercise implementations.
+	exerciseSlug
+	// configUUID items are exercise UUIDs referenced in config.json.
+	configUUID
+	// trackConfig items are track IDs, for problems with config.json as a whole.
+	trackConfig
+)
+
+// newFinding creates a finding for an item returned by a check,
+// filling in the slug and UUID from the track configuration.
+// The path is relative to the track root.
+func newFinding(t track.Track, subject lintSubject, item string) Finding {
+	f := Finding{}
+
+	switch subject {
+	case configSlug:
+		f.Slug = item
+		f.Path = "config.json"
+	case exerciseSlug:
+		f.Slug = item
+		f.Path = "exercises/" + item
+	case configUUID:
+		f.UUID = item
+		f.Path = "config.json"
+	case trackConfig:
+		f.Path = "config.json"
+		return f
+	}
+
+	for _, exercise := range t.Config.Exercises {
+		if f.Slug != "" && exercise.Slug == f.Slug {
+			f.UUID = strings.TrimSpace(exercise.UUID)
+			break
+		}
+		if f.Slug == "" && strings.TrimSpace(exercise.UUID) == f.UUID {
+			f.Slug = exercise.Slug
+			break
+		}
+	}
+	return f
+}
+
+// loadFailure creates a finding for a track that could not be loaded.
+func loadFailure(path string, err error) Finding {
+	return Finding{
+		RuleID:   loadFailureRuleID,
+		Severity: SeverityError,
+		Message:  err.Error(),
+		Path:     filepath.ToSlash(path),
+	}
+}
+
+// locate makes the finding's path relative to the current directory,
+// and points it at the matching exercise entry in config.json, if any.
+func (f *Finding) locate(root string, config []byte) {
+	if f.Path == "config.json" && f.Line == 0 {
+		var offset int
+		switch {
+		case f.Slug != "":
+			offset = valueOffset(config, "slug", f.Slug)
+		case f.UUID != "":
+			offset = valueOffset(config, "uuid", f.UUID)
+		}
+		if offset >= 0 {
+			f.Line, f.Column = lineColumn(config, offset)
+		}
+	}
+	f.Path = filepath.ToSlash(filepath.Join(root, filepath.FromSlash(f.Path)))
+}
+
+// valueOffset returns the byte offset of the first string value for key
+// that equals value, ignoring surrounding whitespace, or -1 if there is none.
+func valueOffset(src []byte, key, value string) int {
+	pattern := `"` + regexp.QuoteMeta(key) + `"\s*:\s*("\s*` + regexp.QuoteMeta(value) + `\s*")`
+	loc := regexp.MustCompile(pattern).FindSubmatchIndex(src)
+	if loc == nil {
+		return -1
+	}
+	return loc[2]
+}
+
+// lineColumn converts a byte offset into 1-based line and column numbers.
+func lineColumn(src []byte, offset int) (int, int) {
+	if offset > len(src) {
+		offset = len(src)
+	}
+	before := src[:offset]
+	line := 1 + strings.Count(string(before), "\n")
+	start := strings.LastIndex(string(before), "\n") + 1
+	return line, 1 + utf8.RuneCount(before[start:])
+}
+
+func isValidLintFormat(format string) bool {
+	for _, f := range lintFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// lintStatus is the overall outcome of linting a track,
+// ordered from best to worst.
+type lintStatus int
+
+const (
+	// lintPassed means there were no findings.
+	lintPassed lintStatus = iota
+	// lintWarned means there were warnings, but no errors.
+	lintWarned
+	// lintFailed means there was at least one error.
+	lintFailed
+)
+
+func (s lintStatus) String() string {
+	switch s {
+	case lintPassed:
+		return "passed"
+	case lintWarned:
+		return "warned"
+	default:
+		return "failed"
+	}
+}
+
+// report writes the findings in the selected format,
+// and returns the resulting status.
+func report(findings []Finding) lintStatus {
+	switch lintFormat {
+	case "json":
+		writeJSON(findings)
+	case "sarif":
+		writeJSON(newSarifLog(findings))
+	default:
+		for _, f := range findings {
+			if f.RuleID == loadFailureRuleID {
+				ui.PrintError(f.Message)
+				continue
+			}
+			if f.Fixed {
+				ui.Print("fixed:", f.Message)
+				continue
+			}
+			if f.Severity == SeverityWarning {
+				ui.Print("warning:", f.Message)
+				continue
+			}
+			ui.Print(f.Message)
+		}
+	}
+	return findingsStatus(findings)
+}
+
+// findingsStatus returns the outcome of the findings. Fixed findings do not count.
+func findingsStatus(findings []Finding) lintStatus {
+	status := lintPassed
+	for _, f := range findings {
+		if f.Fixed {
+			continue
+		}
+		switch f.Severity {
+		case SeverityError:
+			return lintFailed
+		case SeverityWarning:
+			status = lintWarned
+		}
+	}
+	return status
+}
+
+func writeJSON(v interface{}) {
+	enc := json.NewEncoder(ui.Out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		ui.PrintError(err.Error())
+	}
+}
+
+// The sarif types are the subset of the SARIF 2.1.0 format
+// needed to describe lint findings for code scanning tools.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarifLevel converts a severity into a SARIF level.
+// SARIF has no "off" level; rules that do not report anything have the level "none".
+func sarifLevel(s Severity) string {
+	if s == SeverityOff {
+		return "none"
+	}
+	return string(s)
+}
+
+func newSarifLog(findings []Finding) sarifLog {
+	descriptors := []sarifRule{}
+	for _, rule := range rules {
+		descriptors = append(descriptors, sarifRule{
+			ID:                   rule.ID(),
+			ShortDescription:     sarifMessage{Text: rule.Description()},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity())},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, f := range findings {
+		if f.Fixed {
+			continue
+		}
+		result := sarifResult{
+			RuleID:  f.RuleID,
+			Level:   sarifLevel(f.Severity),
+			Message: sarifMessage{Text: f.Message},
+		}
+		if f.Path != "" {
+			loc := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.Path},
+				},
+			}
+			if f.Line > 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line, StartColumn: f.Column}
+			}
+			result.Locations = []sarifLocation{loc}
+		}
+		results = append(results, result)
+	}
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "configlet",
+						Version:        Version,
+						InformationURI: "https://github.com/exercism/configlet",
+						Rules:          descriptors,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/configlet/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintJSONFormat(t *testing.T) {
+	originalNoHTTP := noHTTP
+	originalFormat := lintFormat
+	originalOut := ui.Out
+	noHTTP = true
+	lintFormat = "json"
+	defer func() {
+		noHTTP = originalNoHTTP
+		lintFormat = originalFormat
+		ui.Out = originalOut
+	}()
+
+	var out bytes.Buffer
+	ui.Out = &out
+
+	failed := lintTrack(filepath.FromSlash("../fixtures/numbers")) == lintFailed
+	assert.True(t, failed)
+
+	var findings []Finding
+	if err := json.Unmarshal(out.Bytes(), &findings); err != nil {
+		t.Fatal(err)
+	}
+	implementation := indexOfFinding(findings, "missing-implementation", "bajillion")
+	solution := indexOfFinding(findings, "missing-solution", "three")
+	if implementation < 0 || solution < 0 {
+		t.Fatalf("expected findings of missing-implementation and missing-solution, found %v", findings)
+	}
+
+	assert.Equal(t, Finding{
+		RuleID:   "missing-implementation",
+		Severity: SeverityError,
+		Message:  "An exercise with slug 'bajillion' is referenced in config.json, but no implementation was found.",
+		Slug:     "bajillion",
+		UUID:     "e7f3a1c5-9d2b-4e6f-a8c4-3b5d7f9a1e66",
+		Path:     "../fixtures/numbers/config.json",
+		Line:     28,
+		Column:   15,
+	}, findings[implementation])
+
+	assert.Equal(t, Finding{
+		RuleID:   "missing-solution",
+		Severity: SeverityError,
+		Message:  "The implementation for 'three' is missing an example solution.",
+		Slug:     "three",
+		UUID:     "5d2a7c9e-1b4f-4a6d-8c3e-7f9b1d3a5c55",
+		Path:     "../fixtures/numbers/exercises/three",
+	}, findings[solution])
+}
+
+// indexOfFinding returns the index of the first finding of the rule
+// for the exercise, or -1 if there is none.
+func indexOfFinding(findings []Finding, ruleID, slug string) int {
+	for i, f := range findings {
+		if f.RuleID == ruleID && f.Slug == slug {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestLintSarifFormat(t *testing.T) {
+	originalNoHTTP := noHTTP
+	originalFormat := lintFormat
+	originalOut := ui.Out
+	noHTTP = true
+	lintFormat = "sarif"
+	defer func() {
+		noHTTP = originalNoHTTP
+		lintFormat = originalFormat
+		ui.Out = originalOut
+	}()
+
+	var out bytes.Buffer
+	ui.Out = &out
+
+	lintTrack(filepath.FromSlash("../fixtures/missing-readme"))
+
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Equal(t, 1, len(log.Runs))
+
+	results := log.Runs[0].Results
+	if assert.NotEmpty(t, results) {
+		assert.Equal(t, "missing-readme", results[0].RuleID)
+		assert.Equal(t, "error", results[0].Level)
+		assert.Equal(t, 1, len(results[0].Locations))
+	}
+
+	for _, rule := range log.Runs[0].Tool.Driver.Rules {
+		if rule.ID == "uuid-version" {
+			assert.Equal(t, "none", rule.DefaultConfiguration.Level, "should use a valid SARIF level for rules that are off.")
+		}
+	}
+}
+
+func TestFindingLocation(t *testing.T) {
+	config := []byte(`{
+  "exercises": [
+    {
+      "uuid": "aaa",
+      "slug": "apple"
+    },
+    {
+      "uuid": " bbb ",
+      "slug":"banana"
+    }
+  ]
+}`)
+
+	tests := []struct {
+		finding Finding
+		line    int
+		column  int
+	}{
+		{Finding{Path: "config.json", Slug: "apple"}, 5, 15},
+		{Finding{Path: "config.json", Slug: "banana"}, 9, 14},
+		{Finding{Path: "config.json", UUID: "bbb"}, 8, 15},
+		{Finding{Path: "config.json", Slug: "cherry"}, 0, 0},
+		{Finding{Path: "exercises/apple", Slug: "apple"}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		f := tt.finding
+		f.locate("track", config)
+		assert.Equal(t, tt.line, f.Line, f.Slug+f.UUID)
+		assert.Equal(t, tt.column, f.Column, f.Slug+f.UUID)
+		assert.Equal(t, "track/"+tt.finding.Path, f.Path)
+	}
+}
+
+func TestReportStatus(t *testing.T) {
+	originalOut := ui.Out
+	ui.Out = ioutil.Discard
+	defer func() { ui.Out = originalOut }()
+
+	tests := []struct {
+		desc     string
+		findings []Finding
+		expected lintStatus
+	}{
+		{
+			desc:     "should pass without findings.",
+			findings: []Finding{},
+			expected: lintPassed,
+		},
+		{
+			desc: "should warn when there are only warnings.",
+			findings: []Finding{
+				{RuleID: "missing-unlocks", Severity: SeverityWarning},
+			},
+			expected: lintWarned,
+		},
+		{
+			desc: "should fail when there are errors.",
+			findings: []Finding{
+				{RuleID: "missing-unlocks", Severity: SeverityWarning},
+				{RuleID: "missing-uuid", Severity: SeverityError},
+			},
+			expected: lintFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, report(tt.findings), tt.desc)
+	}
+}
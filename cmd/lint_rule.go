@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/exercism/configlet/track"
+)
+
+// Rule is a lint check that can be run against a track.
+//
+// Track-specific rules can be added by importing this package,
+// registering the rule in an init function, and calling Execute.
+type Rule interface {
+	// ID is a stable identifier for the rule, e.g. "missing-readme".
+	ID() string
+	// Description is a short explanation of what the rule checks.
+	Description() string
+	// Severity is the default severity of the rule's findings.
+	Severity() Severity
+	// Check returns the problems found in the track.
+	// The paths of the findings are relative to the track root.
+	Check(track.Track) []Finding
+}
+
+// rules holds the registered rules in registration order.
+var rules []Rule
+
+// RegisterRule makes a rule available to the lint command.
+// It panics if a rule with the same ID has already been registered.
+func RegisterRule(r Rule) {
+	if _, ok := findRule(r.ID()); ok {
+		panic(fmt.Sprintf("lint rule %q is already registered", r.ID()))
+	}
+	rules = append(rules, r)
+}
+
+// Rules returns the registered rules in the order they are run.
+func Rules() []Rule {
+	return append([]Rule{}, rules...)
+}
+
+func findRule(id string) (Rule, bool) {
+	for _, r := range rules {
+		if r.ID() == id {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// checkRule is a Rule built from a check that returns the failed items,
+// creating a finding with the formatted msg for each of them.
+type checkRule struct {
+	id          string
+	description string
+	severity    Severity
+	check       func(track.Track) []string
+	msg         string
+	subject     lintSubject
+}
+
+func (r checkRule) ID() string          { return r.id }
+func (r checkRule) Description() string { return r.description }
+func (r checkRule) Severity() Severity  { return r.severity }
+
+func (r checkRule) Check(t track.Track) []Finding {
+	items := r.check(t)
+	sort.Strings(items)
+
+	findings := []Finding{}
+	for _, item := range items {
+		f := newFinding(t, r.subject, item)
+		f.RuleID = r.id
+		f.Severity = r.severity
+		f.Message = fmt.Sprintf(r.msg, item)
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// fileRule is a rule about the files in the exercise directories,
+// which reports a finding for each file.
+type fileRule struct {
+	id          string
+	description string
+	severity    Severity
+	// check returns the paths of the files in the exercise to report,
+	// relative to the exercise directory.
+	check func(track.Track, track.Exercise) []string
+	// msg is formatted with the path of the file and the slug of the exercise.
+	msg string
+}
+
+func (r fileRule) ID() string { return r.id }
+
+func (r fileRule) Description() string { return r.description }
+
+func (r fileRule) Severity() Severity { return r.severity }
+
+func (r fileRule) Check(t track.Track) []Finding {
+	deprecated := deprecatedSlugs(t)
+
+	findings := []Finding{}
+	for _, exercise := range t.Exercises {
+		if deprecated[exercise.Slug] {
+			continue
+		}
+		for _, file := range r.check(t, exercise) {
+			f := newFinding(t, exerciseSlug, exercise.Slug)
+			f.RuleID = r.id
+			f.Severity = r.severity
+			f.Message = fmt.Sprintf(r.msg, file, exercise.Slug)
+			f.Path = path.Join(f.Path, file)
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/exercism/configlet/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRule reports an error for every exercise in config.json.
+type fakeRule struct{}
+
+func (fakeRule) ID() string          { return "fake-rule" }
+func (fakeRule) Description() string { return "A rule defined outside of configlet." }
+func (fakeRule) Severity() Severity  { return SeverityError }
+
+func (fakeRule) Check(t track.Track) []Finding {
+	findings := []Finding{}
+	for _, exercise := range t.Config.Exercises {
+		findings = append(findings, Finding{
+			RuleID:   "fake-rule",
+			Severity: SeverityError,
+			Message:  "fake finding for " + exercise.Slug,
+			Slug:     exercise.Slug,
+			Path:     "config.json",
+		})
+	}
+	return findings
+}
+
+func TestRegisterRule(t *testing.T) {
+	originalRules := rules
+	originalEnabled := enabledRules
+	originalOut := ui.Out
+	defer func() {
+		rules = originalRules
+		enabledRules = originalEnabled
+		ui.Out = originalOut
+	}()
+
+	RegisterRule(fakeRule{})
+	assert.Panics(t, func() { RegisterRule(fakeRule{}) }, "should not register a rule ID twice.")
+
+	var out bytes.Buffer
+	ui.Out = &out
+	enabledRules = []string{"fake-rule"}
+
+	failed := lintTrack(filepath.FromSlash("../fixtures/lint/valid-track")) == lintFailed
+	assert.True(t, failed)
+	assert.Equal(t, "-> fake finding for aluminum\n", out.String())
+}
+
+func TestIsRuleEnabled(t *testing.T) {
+	originalEnabled := enabledRules
+	originalDisabled := disabledRules
+	defer func() {
+		enabledRules = originalEnabled
+		disabledRules = originalDisabled
+	}()
+
+	tests := []struct {
+		desc     string
+		enabled  []string
+		disabled []string
+		expected bool
+	}{
+		{
+			desc:     "should run all rules by default.",
+			expected: true,
+		},
+		{
+			desc:     "should run an enabled rule.",
+			enabled:  []string{"missing-readme", "missing-uuid"},
+			expected: true,
+		},
+		{
+			desc:     "should not run a rule that is not enabled.",
+			enabled:  []string{"missing-uuid"},
+			expected: false,
+		},
+		{
+			desc:     "should not run a disabled rule.",
+			disabled: []string{"missing-readme"},
+			expected: false,
+		},
+		{
+			desc:     "should not run a rule that is both enabled and disabled.",
+			enabled:  []string{"missing-readme"},
+			disabled: []string{"missing-readme"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		enabledRules = tt.enabled
+		disabledRules = tt.disabled
+		assert.Equal(t, tt.expected, isRuleEnabled("missing-readme"), tt.desc)
+	}
+}
+
+func TestCheckRuleFindings(t *testing.T) {
+	rule, ok := findRule("missing-uuid")
+	if !ok {
+		t.Fatal("missing-uuid rule is not registered")
+	}
+
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "banana"},
+				{Slug: "apple", UUID: "abc"},
+				{Slug: "cherry"},
+			},
+		},
+	}
+
+	findings := rule.Check(track)
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 findings, found %d", len(findings))
+	}
+	assert.Equal(t, Finding{
+		RuleID:   "missing-uuid",
+		Severity: SeverityError,
+		Message:  "The exercise 'banana' was found in config.json, but does not have a UUID.",
+		Slug:     "banana",
+		Path:     "config.json",
+	}, findings[0])
+	assert.Equal(t, "cherry", findings[1].Slug)
+}
+
+func TestRulesOffByDefault(t *testing.T) {
+	originalEnabled := enabledRules
+	originalFormat := lintFormat
+	originalOut := ui.Out
+	defer func() {
+		enabledRules = originalEnabled
+		lintFormat = originalFormat
+		ui.Out = originalOut
+	}()
+
+	var out bytes.Buffer
+	ui.Out = &out
+	lintFormat = "json"
+
+	enabledRules = []string{"skewed-difficulty"}
+	status := lintTrack(filepath.FromSlash("../fixtures/lint/valid-track"))
+	assert.Equal(t, lintPassed, status, "should not report a track with a single exercise.")
+
+	rule, ok := findRule("skewed-difficulty")
+	if assert.True(t, ok) {
+		assert.Equal(t, SeverityOff, rule.Severity())
+		assert.True(t, isRuleEnabled(rule.ID()))
+	}
+}
+
+func TestEnablingRuleOffByDefault(t *testing.T) {
+	originalEnabled := enabledRules
+	originalNoHTTP := noHTTP
+	originalFormat := lintFormat
+	originalOut := ui.Out
+	defer func() {
+		enabledRules = originalEnabled
+		noHTTP = originalNoHTTP
+		lintFormat = originalFormat
+		ui.Out = originalOut
+	}()
+
+	dir := copyDir(t, filepath.FromSlash("../fixtures/lint/valid-track"))
+	defer os.RemoveAll(dir)
+
+	// Use a version 1 UUID.
+	configPath := filepath.Join(dir, "config.json")
+	src, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src = bytes.Replace(src, []byte("1b0c5e5e-8d3a-4c5f"), []byte("1b0c5e5e-8d3a-1c5f"), 1)
+	if err := ioutil.WriteFile(configPath, src, os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	ui.Out = &out
+	noHTTP = true
+	lintFormat = "json"
+	enabledRules = []string{"uuid-version"}
+
+	assert.Equal(t, lintWarned, lintTrack(dir))
+
+	var findings []Finding
+	if err := json.Unmarshal(out.Bytes(), &findings); err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, "uuid-version", findings[0].RuleID)
+		assert.Equal(t, SeverityWarning, findings[0].Severity)
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/exercism/configlet/track"
+)
+
+// schemaFiles are the track configuration files that are validated against their schemas.
+var schemaFiles = []struct {
+	path   string
+	schema func() *track.Schema
+}{
+	{"config.json", track.ConfigSchema},
+	{"config/maintainers.json", track.MaintainerConfigSchema},
+}
+
+// schemaRule reports the places where the track configuration files do not
+// match their schemas. Unknown keys are reported by a separate rule, as they
+// are kept by fmt, and may be used by tools other than configlet.
+type schemaRule struct {
+	unknown bool
+}
+
+func (r schemaRule) ID() string {
+	if r.unknown {
+		return "unknown-key"
+	}
+	return "schema-violation"
+}
+
+func (r schemaRule) Description() string {
+	if r.unknown {
+		return "The keys in config.json and maintainers.json should be known to configlet, so that they are checked."
+	}
+	return "The values in config.json and maintainers.json must have the types given by their schemas, and the required keys must be present."
+}
+
+func (r schemaRule) Severity() Severity {
+	if r.unknown {
+		return SeverityWarning
+	}
+	return SeverityError
+}
+
+func (r schemaRule) Check(t track.Track) []Finding {
+	findings := []Finding{}
+	for _, file := range schemaFiles {
+		// Missing and invalid files are reported when the track is loaded.
+		data, err := ioutil.ReadFile(filepath.Join(t.Path(), filepath.FromSlash(file.path)))
+		if err != nil {
+			continue
+		}
+		errs, err := file.schema().Validate(data)
+		if err != nil {
+			continue
+		}
+
+		for _, e := range errs {
+			if e.Unknown != r.unknown {
+				continue
+			}
+
+			f := Finding{RuleID: r.ID(), Path: file.path}
+			f.Line, f.Column = lineColumn(data, int(e.Offset))
+			var i int
+			if _, err := fmt.Sscanf(e.Field, "exercises[%d]", &i); err == nil && file.path == "config.json" && i < len(t.Config.Exercises) {
+				f.Slug = t.Config.Exercises[i].Slug
+				f.UUID = t.Config.Exercises[i].UUID
+			}
+
+			field := e.Field
+			if field == "" {
+				field = "the top level"
+			}
+			if r.unknown {
+				f.Message = fmt.Sprintf("The key '%s' in %s is not known to configlet, so it is not checked.", e.Field, file.path)
+			} else {
+				f.Message = fmt.Sprintf("%s does not match its schema at %s: %s.", file.path, field, e.Message)
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+func init() {
+	RegisterRule(schemaRule{})
+	RegisterRule(schemaRule{unknown: true})
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaRules(t *testing.T) {
+	numbers, err := track.New(filepath.FromSlash("../fixtures/numbers"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A missing uuid is left to the missing-uuid rule.
+	assert.Empty(t, schemaRule{}.Check(numbers))
+
+	findings := schemaRule{unknown: true}.Check(numbers)
+	if assert.Equal(t, 2, len(findings)) {
+		assert.Equal(t, "unknown-key", findings[0].RuleID)
+		assert.Equal(t, "The key 'repository' in config.json is not known to configlet, so it is not checked.", findings[0].Message)
+		assert.Equal(t, 4, findings[0].Line)
+		assert.Equal(t, 17, findings[0].Column)
+	}
+
+	valid, err := track.New(filepath.FromSlash("../fixtures/lint/valid-track"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, schemaRule{}.Check(valid))
+	assert.Equal(t, 2, len(schemaRule{unknown: true}.Check(valid)))
+
+	dir, err := ioutil.TempDir("", "schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(filepath.Join(dir, "exercises"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	src := "{\n  \"language\": \"Schema\",\n  \"exercises\": [\n    {\"slug\": \"one\"},\n    {\"uuid\": \"2\"}\n  ]\n}\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(src), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := track.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings = schemaRule{}.Check(schema)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, "schema-violation", findings[0].RuleID)
+		assert.Contains(t, findings[0].Message, `at exercises[1]: missing the required key "slug"`)
+		assert.Equal(t, "config.json", findings[0].Path)
+		assert.Equal(t, 5, findings[0].Line)
+		assert.Equal(t, 5, findings[0].Column)
+	}
+}
@@ -66,7 +66,7 @@ func TestLintTrack(t *testing.T) {
 	}
 
 	for _, tt := range lintTests {
-		failed := lintTrack(filepath.FromSlash(tt.path))
+		failed := lintTrack(filepath.FromSlash(tt.path)) == lintFailed
 		assert.Equal(t, tt.expected, failed, tt.desc)
 	}
 }
@@ -148,7 +148,7 @@ func TestMissingSolution(t *testing.T) {
 	track := track.Track{
 		Exercises: []track.Exercise{
 			{Slug: "apple"},
-			{Slug: "banana", SolutionPath: "b.txt"},
+			{Slug: "banana", SolutionPaths: []string{"b.txt"}},
 			{Slug: "cherry"},
 		},
 	}
@@ -169,7 +169,7 @@ func TestMissingTestSuite(t *testing.T) {
 	track := track.Track{
 		Exercises: []track.Exercise{
 			{Slug: "apple"},
-			{Slug: "banana", TestSuitePath: "b_test.ext"},
+			{Slug: "banana", TestSuitePaths: []string{"b_test.ext"}},
 			{Slug: "cherry"},
 		},
 	}
@@ -306,7 +306,8 @@ func TestDuplicateTrackUUID(t *testing.T) {
 		},
 	}
 
-	uuids := duplicateTrackUUID(track)
+	uuids, err := duplicateTrackUUID(track)
+	assert.NoError(t, err)
 	assert.Equal(t, len(expected), len(uuids))
 	assert.Equal(t, expected[0], uuids[0])
 
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/exercism/configlet/track"
+)
+
+// topicsPath flag is the location of a file with the topics to accept,
+// instead of the canonical vocabulary.
+var topicsPath string
+
+// maxSuggestionDistance is the largest edit distance between an unknown
+// topic and a known topic for the known topic to be suggested instead.
+const maxSuggestionDistance = 3
+
+// parseTopics reads a topic vocabulary with one topic per line,
+// skipping blank lines and comments.
+func parseTopics(s string) map[string]bool {
+	topics := map[string]bool{}
+
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		topics[track.NormalizeTopic(line)] = true
+	}
+	return topics
+}
+
+// loadTopics returns the topic vocabulary given by --topics-path,
+// or the canonical vocabulary if the flag is not set.
+func loadTopics() (map[string]bool, error) {
+	if topicsPath == "" {
+		return parseTopics(defaultTopics), nil
+	}
+
+	b, err := ioutil.ReadFile(topicsPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseTopics(string(b)), nil
+}
+
+// closestTopic returns the known topic with the smallest edit distance
+// to the topic, if it is close enough to be a suggestion.
+func closestTopic(vocabulary map[string]bool, topic string) (string, bool) {
+	known := make([]string, 0, len(vocabulary))
+	for k := range vocabulary {
+		known = append(known, k)
+	}
+	sort.Strings(known)
+
+	closest, distance := "", maxSuggestionDistance+1
+	for _, k := range known {
+		if d := editDistance(topic, k); d < distance {
+			closest, distance = k, d
+		}
+	}
+	return closest, closest != ""
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	row := make([]int, len(t)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		diagonal := row[0]
+		row[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			above := row[j]
+			row[j] = min3(row[j]+1, row[j-1]+1, diagonal+cost)
+			diagonal = above
+		}
+	}
+	return row[len(t)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// unknownTopicRule reports topics that are not in the topic vocabulary,
+// suggesting the closest known topic where there is one.
+type unknownTopicRule struct{}
+
+func (unknownTopicRule) ID() string { return "unknown-topic" }
+
+func (unknownTopicRule) Description() string {
+	return "Exercise topics should be in the topic vocabulary (see --topics-path)."
+}
+
+func (unknownTopicRule) Severity() Severity { return SeverityWarning }
+
+func (r unknownTopicRule) Check(t track.Track) []Finding {
+	vocabulary, err := loadTopics()
+	if err != nil {
+		return []Finding{{
+			RuleID:   r.ID(),
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("The topic vocabulary could not be read: %s", err),
+			Path:     "config.json",
+		}}
+	}
+
+	findings := []Finding{}
+	for _, exercise := range activeExercises(t) {
+		for _, topic := range exercise.Topics {
+			normalized := track.NormalizeTopic(topic)
+			if vocabulary[normalized] {
+				continue
+			}
+
+			f := newFinding(t, configSlug, exercise.Slug)
+			f.RuleID = r.ID()
+			f.Message = fmt.Sprintf("The exercise '%s' has the unknown topic '%s'.", exercise.Slug, topic)
+			if suggestion, ok := closestTopic(vocabulary, normalized); ok {
+				f.Message = fmt.Sprintf("The exercise '%s' has the unknown topic '%s', did you mean '%s'?", exercise.Slug, topic, suggestion)
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+func missingTopics(t track.Track) []string {
+	slugs := []string{}
+	for _, exercise := range activeExercises(t) {
+		if len(exercise.Topics) == 0 {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func init() {
+	RegisterRule(unknownTopicRule{})
+	RegisterRule(checkRule{
+		id:          "missing-topics",
+		description: "Exercises should have topics.",
+		severity:    SeverityWarning,
+		check:       missingTopics,
+		msg:         "The exercise '%v' does not have any topics.",
+		subject:     configSlug,
+	})
+
+	lintCmd.Flags().StringVar(&topicsPath, "topics-path", "", "The location of a file listing the known topics, one per line.")
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTopics(t *testing.T) {
+	topics := parseTopics("# Fruit\napple\n\nBanana Split\n  cherry  \n")
+	assert.Equal(t, map[string]bool{"apple": true, "banana_split": true, "cherry": true}, topics)
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		distance int
+	}{
+		{"", "", 0},
+		{"apple", "apple", 0},
+		{"", "apple", 5},
+		{"recursion", "recusion", 1},
+		{"strings", "sting", 2},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.distance, editDistance(tt.a, tt.b), tt.a+" -> "+tt.b)
+		assert.Equal(t, tt.distance, editDistance(tt.b, tt.a), tt.b+" -> "+tt.a)
+	}
+}
+
+func TestUnknownTopics(t *testing.T) {
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", Topics: []string{"Strings", "recusion"}},
+				{Slug: "banana", Topics: []string{"zymurgy"}},
+				{Slug: "cherry", Topics: []string{"zymurgy"}, IsDeprecated: true},
+			},
+		},
+	}
+
+	findings := unknownTopicRule{}.Check(track)
+	if len(findings) != 2 {
+		t.Fatalf("Expected 2 unknown topics, found %d", len(findings))
+	}
+	assert.Equal(t, "The exercise 'apple' has the unknown topic 'recusion', did you mean 'recursion'?", findings[0].Message)
+	assert.Equal(t, "apple", findings[0].Slug)
+	assert.Equal(t, "The exercise 'banana' has the unknown topic 'zymurgy'.", findings[1].Message)
+}
+
+func TestTopicsPath(t *testing.T) {
+	originalTopicsPath := topicsPath
+	defer func() { topicsPath = originalTopicsPath }()
+
+	dir, err := ioutil.TempDir("", "topics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	topicsPath = filepath.Join(dir, "TOPICS.txt")
+	if err := ioutil.WriteFile(topicsPath, []byte("zymurgy\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", Topics: []string{"zymurgy", "strings"}},
+			},
+		},
+	}
+
+	findings := unknownTopicRule{}.Check(track)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, "The exercise 'apple' has the unknown topic 'strings'.", findings[0].Message)
+	}
+
+	topicsPath = filepath.Join(dir, "missing.txt")
+	findings = unknownTopicRule{}.Check(track)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, SeverityError, findings[0].Severity)
+	}
+}
+
+func TestMissingTopics(t *testing.T) {
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", Topics: []string{"strings"}},
+				{Slug: "banana", Topics: []string{}},
+				{Slug: "cherry"},
+				{Slug: "durian", IsDeprecated: true},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"banana", "cherry"}, missingTopics(track))
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/exercism/configlet/ui"
+)
+
+var (
+	// lintAll flag treats the arguments as directories of tracks, and lints every track in them.
+	lintAll bool
+	// lintJobs flag is the number of tracks that are linted at the same time.
+	lintJobs int
+)
+
+// findTracks returns the subdirectories of dir that are track roots,
+// that is, that contain a config.json file.
+func findTracks(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, info.Name())
+		if _, err := os.Stat(filepath.Join(path, "config.json")); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// trackPaths returns the tracks to lint for the arguments.
+// With --all, every track in each of the directories is linted.
+func trackPaths(args []string) ([]string, error) {
+	if !lintAll {
+		return args, nil
+	}
+
+	paths := []string{}
+	for _, dir := range args {
+		tracks, err := findTracks(dir)
+		if err != nil {
+			return nil, err
+		}
+		if len(tracks) == 0 {
+			return nil, fmt.Errorf("no tracks found in %s", dir)
+		}
+		paths = append(paths, tracks...)
+	}
+	return paths, nil
+}
+
+// lintTracks lints the tracks concurrently, using at most --jobs workers,
+// and reports the findings in the order of the tracks, followed by a summary.
+// It returns the worst status of all of the tracks.
+func lintTracks(paths []string) lintStatus {
+	if len(paths) == 1 {
+		return lintTrack(paths[0])
+	}
+
+	results := make([][]Finding, len(paths))
+	diffs := make([]string, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := lintJobs
+	if workers < 1 {
+		workers = 1
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], diffs[i] = lintFindings(paths[i])
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	status := lintPassed
+	for _, findings := range results {
+		if s := findingsStatus(findings); s > status {
+			status = s
+		}
+	}
+
+	if lintFormat != "text" {
+		// Machine-readable formats get a single document, as each finding
+		// includes the path of its track.
+		all := []Finding{}
+		for i, findings := range results {
+			printDryRun(diffs[i])
+			all = append(all, findings...)
+		}
+		report(all)
+		return status
+	}
+
+	for i, findings := range results {
+		if len(findings) == 0 {
+			continue
+		}
+		ui.Print(paths[i])
+		printDryRun(diffs[i])
+		report(findings)
+	}
+	printSummary(paths, results)
+	return status
+}
+
+// printSummary writes a table with the number of errors, warnings and fixes
+// for each of the tracks.
+func printSummary(paths []string, results [][]Finding) {
+	w := tabwriter.NewWriter(ui.Out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "TRACK\tERRORS\tWARNINGS\tFIXED\tSTATUS\n")
+	for i, findings := range results {
+		var errors, warnings, fixed int
+		for _, f := range findings {
+			switch {
+			case f.Fixed:
+				fixed++
+			case f.Severity == SeverityError:
+				errors++
+			case f.Severity == SeverityWarning:
+				warnings++
+			}
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", paths[i], errors, warnings, fixed, findingsStatus(findings))
+	}
+	w.Flush()
+}
+
+func init() {
+	lintCmd.Flags().BoolVar(&lintAll, "all", false, "Lint every track in the given directories.")
+	lintCmd.Flags().IntVar(&lintJobs, "jobs", runtime.NumCPU(), "The number of tracks to lint at the same time.")
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/exercism/configlet/ui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTracks(t *testing.T) {
+	dir := filepath.FromSlash("../fixtures/lint")
+	paths, err := findTracks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{
+		filepath.Join(dir, "configured-track"),
+		filepath.Join(dir, "deprecated-track"),
+		filepath.Join(dir, "fixable-track"),
+		filepath.Join(dir, "valid-track"),
+	}, paths)
+
+	_, err = findTracks(filepath.FromSlash("../fixtures/no-such-dir"))
+	assert.Error(t, err)
+}
+
+func TestTrackPaths(t *testing.T) {
+	originalAll := lintAll
+	defer func() { lintAll = originalAll }()
+
+	args := []string{filepath.FromSlash("../fixtures/lint"), filepath.FromSlash("../fixtures/format")}
+
+	lintAll = false
+	paths, err := trackPaths(args)
+	assert.NoError(t, err)
+	assert.Equal(t, args, paths)
+
+	lintAll = true
+	paths, err = trackPaths(args)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(args[0], "configured-track"),
+		filepath.Join(args[0], "deprecated-track"),
+		filepath.Join(args[0], "fixable-track"),
+		filepath.Join(args[0], "valid-track"),
+		filepath.Join(args[1], "formatted"),
+		filepath.Join(args[1], "malformed"),
+		filepath.Join(args[1], "semantics"),
+		filepath.Join(args[1], "unformatted"),
+		filepath.Join(args[1], "unknown-keys"),
+	}, paths)
+
+	_, err = trackPaths([]string{filepath.FromSlash("../fixtures/lint/valid-track")})
+	assert.Error(t, err, "should fail when a directory does not contain any tracks.")
+}
+
+func TestLintTracks(t *testing.T) {
+	originalNoHTTP := noHTTP
+	originalJobs := lintJobs
+	originalFormat := lintFormat
+	originalOut := ui.Out
+	originalEnabled := enabledRules
+	noHTTP = true
+	lintJobs = 2
+	// Only the rules under test run, so that new rules do not change the output.
+	enabledRules = []string{"missing-test-suite", "unknown-key", "missing-implementation"}
+	defer func() {
+		noHTTP = originalNoHTTP
+		lintJobs = originalJobs
+		lintFormat = originalFormat
+		ui.Out = originalOut
+		enabledRules = originalEnabled
+	}()
+
+	var out bytes.Buffer
+	ui.Out = &out
+
+	paths := []string{
+		filepath.FromSlash("../fixtures/lint/configured-track"),
+		filepath.FromSlash("../fixtures/lint/valid-track"),
+	}
+	assert.Equal(t, lintWarned, lintTracks(paths))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, "-> "+paths[0], lines[0])
+	assert.Equal(t, "-> warning: The implementation for 'carbon' is missing a test suite.", lines[1])
+	assert.Equal(t, "-> warning: The key 'repository' in config.json is not known to configlet, so it is not checked.", lines[2])
+	assert.Equal(t, "-> warning: The key 'slug' in config.json is not known to configlet, so it is not checked.", lines[3])
+	assert.Equal(t, "-> "+paths[1], lines[4])
+	assert.Regexp(t, `^TRACK\s+ERRORS\s+WARNINGS\s+FIXED\s+STATUS$`, lines[len(lines)-3])
+	assert.Regexp(t, `configured-track\s+0\s+3\s+0\s+warned$`, lines[len(lines)-2])
+	assert.Regexp(t, `valid-track\s+0\s+2\s+0\s+warned$`, lines[len(lines)-1])
+
+	// The worst status wins, and machine-readable output is a single document.
+	out.Reset()
+	lintFormat = "json"
+	paths = append(paths, filepath.FromSlash("../fixtures/numbers"))
+	assert.Equal(t, lintFailed, lintTracks(paths))
+
+	var findings []Finding
+	if err := json.Unmarshal(out.Bytes(), &findings); err != nil {
+		t.Fatal(err)
+	}
+	carbon := indexOfFinding(findings, "missing-test-suite", "carbon")
+	bajillion := indexOfFinding(findings, "missing-implementation", "bajillion")
+	assert.Equal(t, 0, carbon)
+	assert.True(t, bajillion > carbon, "should list the findings in the order of the tracks.")
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"github.com/exercism/configlet/track"
+)
+
+// maxUnlocks flag is the number of side exercises a core exercise may unlock.
+// Zero means there is no limit.
+var maxUnlocks int
+
+// deprecatedSlugs returns the slugs of the deprecated exercises,
+// whether they are listed as deprecated or flagged as deprecated.
+func deprecatedSlugs(t track.Track) map[string]bool {
+	deprecated := map[string]bool{}
+	for _, slug := range t.Config.DeprecatedSlugs {
+		deprecated[slug] = true
+	}
+	for _, exercise := range t.Config.Exercises {
+		if exercise.IsDeprecated {
+			deprecated[exercise.Slug] = true
+		}
+	}
+	return deprecated
+}
+
+// activeExercises returns the exercises in config.json that are not deprecated.
+func activeExercises(t track.Track) []track.ExerciseMetadata {
+	deprecated := deprecatedSlugs(t)
+
+	exercises := []track.ExerciseMetadata{}
+	for _, exercise := range t.Config.Exercises {
+		if !deprecated[exercise.Slug] {
+			exercises = append(exercises, exercise)
+		}
+	}
+	return exercises
+}
+
+func unknownUnlockedBy(t track.Track) []string {
+	known := map[string]bool{}
+	for _, exercise := range t.Config.Exercises {
+		known[exercise.Slug] = true
+	}
+
+	slugs := []string{}
+	for _, exercise := range activeExercises(t) {
+		if exercise.UnlockedBy != nil && !known[*exercise.UnlockedBy] {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func deprecatedUnlockedBy(t track.Track) []string {
+	deprecated := deprecatedSlugs(t)
+
+	slugs := []string{}
+	for _, exercise := range activeExercises(t) {
+		if exercise.UnlockedBy != nil && deprecated[*exercise.UnlockedBy] {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func selfUnlocks(t track.Track) []string {
+	slugs := []string{}
+	for _, exercise := range activeExercises(t) {
+		if exercise.UnlockedBy != nil && *exercise.UnlockedBy == exercise.Slug {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+// unlockChain follows the unlocked_by references from the exercise until it
+// reaches an exercise that is not unlocked by another one, or a reference
+// that cannot be followed. It returns the slugs in the chain, starting with
+// the exercise, and whether the chain loops back on itself.
+func unlockChain(exercises map[string]track.ExerciseMetadata, slug string) ([]string, bool) {
+	chain := []string{}
+	seen := map[string]bool{}
+	for {
+		if seen[slug] {
+			return chain, true
+		}
+		seen[slug] = true
+		chain = append(chain, slug)
+
+		exercise, ok := exercises[slug]
+		if !ok || exercise.UnlockedBy == nil {
+			return chain, false
+		}
+		slug = *exercise.UnlockedBy
+	}
+}
+
+func unlockCycles(t track.Track) []string {
+	exercises := map[string]track.ExerciseMetadata{}
+	for _, exercise := range activeExercises(t) {
+		exercises[exercise.Slug] = exercise
+	}
+
+	slugs := []string{}
+	for slug := range exercises {
+		chain, loops := unlockChain(exercises, slug)
+		// Only report the exercises that are part of the loop itself,
+		// not the ones that lead into it. Self-unlocks are reported separately.
+		if loops && len(chain) > 1 && *exercises[chain[len(chain)-1]].UnlockedBy == slug {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+// unreachableExercises returns the exercises whose unlock chain ends in an
+// exercise that is not core. Chains that end in a reference to an unknown or
+// deprecated exercise, or that loop back on themselves, are reported by other
+// rules, so they are not reported again.
+func unreachableExercises(t track.Track) []string {
+	exercises := map[string]track.ExerciseMetadata{}
+	for _, exercise := range activeExercises(t) {
+		exercises[exercise.Slug] = exercise
+	}
+
+	slugs := []string{}
+	for slug, exercise := range exercises {
+		// Exercises without unlocked_by are available from the start.
+		if exercise.UnlockedBy == nil {
+			continue
+		}
+		chain, loops := unlockChain(exercises, slug)
+		root, ok := exercises[chain[len(chain)-1]]
+		if loops || !ok {
+			continue
+		}
+		if !root.IsCore {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+// unlockCounts returns the number of exercises unlocked by each core exercise.
+func unlockCounts(t track.Track) map[string]int {
+	exercises := activeExercises(t)
+
+	counts := map[string]int{}
+	for _, exercise := range exercises {
+		if exercise.IsCore {
+			counts[exercise.Slug] = 0
+		}
+	}
+	for _, exercise := range exercises {
+		if exercise.UnlockedBy == nil {
+			continue
+		}
+		if _, ok := counts[*exercise.UnlockedBy]; ok {
+			counts[*exercise.UnlockedBy]++
+		}
+	}
+	return counts
+}
+
+func coreWithoutUnlocks(t track.Track) []string {
+	slugs := []string{}
+	for slug, count := range unlockCounts(t) {
+		if count == 0 {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+func tooManyUnlocks(t track.Track) []string {
+	slugs := []string{}
+	if maxUnlocks <= 0 {
+		return slugs
+	}
+	for slug, count := range unlockCounts(t) {
+		if count > maxUnlocks {
+			slugs = append(slugs, slug)
+		}
+	}
+	return slugs
+}
+
+func init() {
+	RegisterRule(checkRule{
+		id:          "unknown-unlocked-by",
+		description: "Exercises can only be unlocked by exercises in config.json.",
+		severity:    SeverityError,
+		check:       unknownUnlockedBy,
+		msg:         "The exercise '%v' is unlocked by an exercise that is not in config.json.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "deprecated-unlocked-by",
+		description: "Exercises must not be unlocked by deprecated exercises.",
+		severity:    SeverityError,
+		check:       deprecatedUnlockedBy,
+		msg:         "The exercise '%v' is unlocked by a deprecated exercise.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "self-unlock",
+		description: "Exercises must not unlock themselves.",
+		severity:    SeverityError,
+		check:       selfUnlocks,
+		msg:         "The exercise '%v' is unlocked by itself.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "unlock-cycle",
+		description: "Exercises must not unlock each other in a cycle.",
+		severity:    SeverityError,
+		check:       unlockCycles,
+		msg:         "The exercise '%v' is part of a cycle of exercises that unlock each other.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "unreachable-exercise",
+		description: "Exercises that are unlocked by another exercise must be reachable from a core exercise.",
+		severity:    SeverityError,
+		check:       unreachableExercises,
+		msg:         "The exercise '%v' can never be unlocked, as it cannot be reached from a core exercise.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "core-without-unlocks",
+		description: "Core exercises should unlock side exercises.",
+		severity:    SeverityWarning,
+		check:       coreWithoutUnlocks,
+		msg:         "The core exercise '%v' does not unlock any exercises.",
+		subject:     configSlug,
+	})
+	RegisterRule(checkRule{
+		id:          "too-many-unlocks",
+		description: "Core exercises should not unlock more side exercises than --max-unlocks.",
+		severity:    SeverityWarning,
+		check:       tooManyUnlocks,
+		msg:         "The core exercise '%v' unlocks more exercises than the maximum set by --max-unlocks.",
+		subject:     configSlug,
+	})
+
+	lintCmd.Flags().IntVar(&maxUnlocks, "max-unlocks", 0, "The most exercises a core exercise may unlock (0 for no limit).")
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	cherry   = "cherry"
+	durian   = "durian"
+	honeydew = "honeydew"
+	kiwi     = "kiwi"
+	lime     = "lime"
+	orange   = "orange"
+)
+
+func TestUnlockGraphChecks(t *testing.T) {
+	config := track.Config{
+		Exercises: []track.ExerciseMetadata{
+			{Slug: "apple", IsCore: true},
+			{Slug: "banana", UnlockedBy: &apple},
+			{Slug: "cherry", UnlockedBy: &durian},
+			{Slug: "durian", UnlockedBy: &cherry},
+			{Slug: "eggplant", UnlockedBy: &unknown},
+			{Slug: "fig", UnlockedBy: &banana},
+			{Slug: "grape", IsCore: true},
+			{Slug: "honeydew", UnlockedBy: &honeydew},
+			{Slug: "kiwi", IsCore: true, IsDeprecated: true},
+			{Slug: "lemon", UnlockedBy: &kiwi},
+			{Slug: "mango", UnlockedBy: &lime},
+			{Slug: "nectarine", UnlockedBy: &cherry},
+			{Slug: "orange"},
+			{Slug: "papaya", UnlockedBy: &orange},
+		},
+		DeprecatedSlugs: []string{"lime"},
+	}
+
+	tests := []struct {
+		desc     string
+		check    func(track.Track) []string
+		expected []string
+	}{
+		{
+			desc:     "should report exercises unlocked by unknown exercises.",
+			check:    unknownUnlockedBy,
+			expected: []string{"eggplant", "mango"},
+		},
+		{
+			desc:     "should report exercises unlocked by deprecated exercises.",
+			check:    deprecatedUnlockedBy,
+			expected: []string{"lemon", "mango"},
+		},
+		{
+			desc:     "should report exercises that unlock themselves.",
+			check:    selfUnlocks,
+			expected: []string{"honeydew"},
+		},
+		{
+			desc:     "should report exercises that unlock each other.",
+			check:    unlockCycles,
+			expected: []string{"cherry", "durian"},
+		},
+		{
+			desc:     "should report exercises that cannot be reached from a core exercise, but not those whose references other rules report.",
+			check:    unreachableExercises,
+			expected: []string{"papaya"},
+		},
+		{
+			desc:     "should report core exercises that do not unlock anything.",
+			check:    coreWithoutUnlocks,
+			expected: []string{"grape"},
+		},
+	}
+
+	for _, tt := range tests {
+		slugs := tt.check(track.Track{Config: config})
+		sort.Strings(slugs)
+		assert.Equal(t, tt.expected, slugs, tt.desc)
+	}
+}
+
+func TestTooManyUnlocks(t *testing.T) {
+	originalMaxUnlocks := maxUnlocks
+	defer func() { maxUnlocks = originalMaxUnlocks }()
+
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", IsCore: true},
+				{Slug: "banana", UnlockedBy: &apple},
+				{Slug: "cherry", UnlockedBy: &apple},
+				{Slug: "durian", IsCore: true},
+				{Slug: "eggplant", UnlockedBy: &durian},
+			},
+		},
+	}
+
+	maxUnlocks = 0
+	assert.Empty(t, tooManyUnlocks(track), "should not limit unlocks by default.")
+
+	maxUnlocks = 1
+	assert.Equal(t, []string{"apple"}, tooManyUnlocks(track))
+
+	maxUnlocks = 2
+	assert.Empty(t, tooManyUnlocks(track))
+}
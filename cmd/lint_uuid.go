@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/exercism/configlet/track"
+)
+
+// uuidURLEnv is the environment variable that can set the location
+// of the UUID validation service.
+const uuidURLEnv = "CONFIGLET_UUID_URL"
+
+var (
+	// uuidURL flag overrides the location of the UUID validation service.
+	uuidURL string
+	// httpTimeout flag is how long a request to the UUID validation service may take.
+	httpTimeout time.Duration
+	// httpRetries flag is how often a failed request to the UUID validation service is retried.
+	httpRetries int
+	// retryBackoff is how long to wait before the first retry.
+	// The wait doubles for every retry after that.
+	retryBackoff = time.Second
+
+	// tracksDir flag is a directory of track checkouts to check UUIDs against,
+	// instead of using the UUID validation service.
+	tracksDir string
+	// uuidIndexPath flag is a UUID index file to check UUIDs against,
+	// instead of using the UUID validation service.
+	uuidIndexPath string
+)
+
+// uuidOwner is an exercise that uses a UUID.
+// A UUID index file is a JSON array of owners.
+type uuidOwner struct {
+	TrackID string `json:"track_id"`
+	Slug    string `json:"slug"`
+	UUID    string `json:"uuid"`
+	// Path is the directory of the track, for tracks found in --tracks-dir.
+	Path string `json:"-"`
+}
+
+// uuidIndex maps UUIDs to the exercises that use them.
+type uuidIndex map[string][]uuidOwner
+
+func (index uuidIndex) add(owner uuidOwner) {
+	owner.UUID = strings.TrimSpace(owner.UUID)
+	if owner.UUID == "" {
+		return
+	}
+	index[owner.UUID] = append(index[owner.UUID], owner)
+}
+
+// loadUUIDIndex builds the index from the --tracks-dir and --uuid-index flags.
+func loadUUIDIndex() (uuidIndex, error) {
+	index := uuidIndex{}
+
+	if uuidIndexPath != "" {
+		b, err := ioutil.ReadFile(uuidIndexPath)
+		if err != nil {
+			return nil, err
+		}
+		var owners []uuidOwner
+		if err := json.Unmarshal(b, &owners); err != nil {
+			return nil, fmt.Errorf("invalid UUID index %s -- %s", uuidIndexPath, err.Error())
+		}
+		for _, owner := range owners {
+			index.add(owner)
+		}
+	}
+
+	if tracksDir != "" {
+		paths, err := findTracks(tracksDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			cfg, err := track.NewConfig(filepath.Join(path, "config.json"))
+			if err != nil {
+				return nil, err
+			}
+			for _, exercise := range cfg.Exercises {
+				index.add(uuidOwner{TrackID: filepath.Base(path), Slug: exercise.Slug, UUID: exercise.UUID, Path: path})
+			}
+		}
+	}
+	return index, nil
+}
+
+// uuidIndexCache holds the index built from the flags, so that it is only
+// built once when several tracks are linted.
+var uuidIndexCache struct {
+	sync.Mutex
+	loaded    bool
+	tracksDir string
+	indexPath string
+	index     uuidIndex
+	err       error
+}
+
+// cachedUUIDIndex returns the index built from the --tracks-dir and --uuid-index flags,
+// building it the first time it is needed.
+func cachedUUIDIndex() (uuidIndex, error) {
+	c := &uuidIndexCache
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.loaded || c.tracksDir != tracksDir || c.indexPath != uuidIndexPath {
+		c.index, c.err = loadUUIDIndex()
+		c.loaded, c.tracksDir, c.indexPath = true, tracksDir, uuidIndexPath
+	}
+	return c.index, c.err
+}
+
+// isSameTrack reports whether the owner of a UUID is the track itself.
+// Tracks found in --tracks-dir are named after their directory, which may
+// differ from the track's ID, so their directories are compared as well.
+func isSameTrack(owner uuidOwner, t track.Track) bool {
+	if owner.TrackID == t.ID {
+		return true
+	}
+	if owner.Path == "" || t.Path() == "" {
+		return false
+	}
+	a, err := os.Stat(owner.Path)
+	if err != nil {
+		return false
+	}
+	b, err := os.Stat(t.Path())
+	if err != nil {
+		return false
+	}
+	return os.SameFile(a, b)
+}
+
+// uuidServiceURL returns the location of the UUID validation service,
+// as set by the --uuid-url flag, the environment, or the default.
+func uuidServiceURL() string {
+	if uuidURL != "" {
+		return uuidURL
+	}
+	if url := os.Getenv(uuidURLEnv); url != "" {
+		return url
+	}
+	return UUIDValidationURL
+}
+
+// postWithRetries posts the JSON body to url, retrying with an increasing
+// wait when the request fails or the server reports an error.
+func postWithRetries(url string, body []byte) (*http.Response, error) {
+	client := &http.Client{Timeout: httpTimeout}
+	wait := retryBackoff
+
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if attempt >= httpRetries {
+			return resp, err
+		}
+		if err == nil {
+			resp.Body.Close()
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// trackUUIDRule checks that exercise UUIDs are unique across tracks.
+// It uses local track checkouts or a UUID index when they are given,
+// and Exercism's UUID validation service otherwise. The embedded checkRule
+// reports the conflicts found by the service.
+type trackUUIDRule struct {
+	checkRule
+}
+
+func (r trackUUIDRule) Check(t track.Track) []Finding {
+	if tracksDir == "" && uuidIndexPath == "" {
+		return r.checkService(t)
+	}
+
+	index, err := cachedUUIDIndex()
+	if err != nil {
+		return []Finding{{
+			RuleID:   r.ID(),
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("The UUIDs of other tracks could not be read: %s", err),
+			Path:     "config.json",
+		}}
+	}
+
+	findings := []Finding{}
+	for _, exercise := range t.Config.Exercises {
+		for _, owner := range index[strings.TrimSpace(exercise.UUID)] {
+			if isSameTrack(owner, t) {
+				continue
+			}
+			f := newFinding(t, configSlug, exercise.Slug)
+			f.RuleID = r.ID()
+			f.Severity = r.Severity()
+			f.Message = fmt.Sprintf("The UUID of the exercise '%s' is already used by the exercise '%s' in the '%s' track. Each exercise UUID must be unique across tracks.\n%s",
+				exercise.Slug, owner.Slug, owner.TrackID, f.UUID)
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// checkService checks the UUIDs with the UUID validation service.
+// As the service being unavailable says nothing about the track,
+// it is reported as a warning.
+func (r trackUUIDRule) checkService(t track.Track) []Finding {
+	uuids, err := duplicateTrackUUID(t)
+	if err != nil {
+		return []Finding{{
+			RuleID:   r.ID(),
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("The UUID validation service could not be used, so UUIDs were not checked across tracks: %s", err),
+			Path:     "config.json",
+		}}
+	}
+
+	rule := r.checkRule
+	rule.check = func(track.Track) []string { return uuids }
+	return rule.Check(t)
+}
+
+func init() {
+	lintCmd.Flags().StringVar(&uuidURL, "uuid-url", "", "The location of the UUID validation service (defaults to $"+uuidURLEnv+" or "+UUIDValidationURL+").")
+	lintCmd.Flags().DurationVar(&httpTimeout, "http-timeout", 10*time.Second, "How long a request to the UUID validation service may take.")
+	lintCmd.Flags().IntVar(&httpRetries, "http-retries", 2, "How often to retry a failed request to the UUID validation service.")
+	lintCmd.Flags().StringVar(&tracksDir, "tracks-dir", "", "Check UUIDs against the tracks checked out in this directory, instead of over HTTP.")
+	lintCmd.Flags().StringVar(&uuidIndexPath, "uuid-index", "", "Check UUIDs against this UUID index file, instead of over HTTP.")
+}
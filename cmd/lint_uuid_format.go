@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/exercism/configlet/track"
+	"github.com/google/uuid"
+)
+
+// uuidVersion is the version of the UUIDs generated by configlet uuid.
+const uuidVersion = 4
+
+// parseUUID parses a UUID in any of the forms accepted by the uuid package,
+// ignoring surrounding whitespace.
+func parseUUID(s string) (uuid.UUID, error) {
+	return uuid.Parse(strings.TrimSpace(s))
+}
+
+// malformedUUIDs returns the slugs of the exercises whose UUID cannot be parsed.
+// Missing UUIDs are reported by missing-uuid instead.
+func malformedUUIDs(t track.Track) []string {
+	slugs := []string{}
+	for _, exercise := range t.Config.Exercises {
+		if exercise.UUID == "" {
+			continue
+		}
+		if _, err := parseUUID(exercise.UUID); err != nil {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+// nonCanonicalUUIDs returns the slugs of the exercises whose UUID can be parsed,
+// but is not written in the canonical lowercase, hyphenated form.
+func nonCanonicalUUIDs(t track.Track) []string {
+	slugs := []string{}
+	for _, exercise := range t.Config.Exercises {
+		id, err := parseUUID(exercise.UUID)
+		if err == nil && id.String() != exercise.UUID {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func unversionedUUIDs(t track.Track) []string {
+	slugs := []string{}
+	for _, exercise := range t.Config.Exercises {
+		id, err := parseUUID(exercise.UUID)
+		if err == nil && id.Version() != uuidVersion {
+			slugs = append(slugs, exercise.Slug)
+		}
+	}
+	return slugs
+}
+
+func fixNonCanonicalUUID(cfg *track.Config, f Finding) bool {
+	for i, exercise := range cfg.Exercises {
+		if exercise.Slug != f.Slug {
+			continue
+		}
+		id, err := parseUUID(exercise.UUID)
+		if err != nil || id.String() == exercise.UUID {
+			return false
+		}
+		cfg.Exercises[i].UUID = id.String()
+		return true
+	}
+	return false
+}
+
+func init() {
+	RegisterRule(checkRule{
+		id:          "invalid-uuid",
+		description: "Exercise UUIDs must be valid UUIDs.",
+		severity:    SeverityError,
+		check:       malformedUUIDs,
+		msg:         "The exercise '%v' has a UUID that is not a valid UUID. Generate one with 'configlet uuid'.",
+		subject:     configSlug,
+	})
+	RegisterRule(fixableRule{
+		checkRule: checkRule{
+			id:          "non-canonical-uuid",
+			description: "Exercise UUIDs must be lowercase and hyphenated, without braces or whitespace.",
+			severity:    SeverityError,
+			check:       nonCanonicalUUIDs,
+			msg:         "The exercise '%v' has a UUID that is not in the canonical lowercase, hyphenated form.",
+			subject:     configSlug,
+		},
+		fix: fixNonCanonicalUUID,
+	})
+	RegisterRule(checkRule{
+		id:          "uuid-version",
+		description: "Exercise UUIDs should be version 4 UUIDs, as generated by 'configlet uuid'.",
+		severity:    SeverityOff,
+		check:       unversionedUUIDs,
+		msg:         "The exercise '%v' has a UUID that is not a version 4 UUID.",
+		subject:     configSlug,
+	})
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUIDFormat(t *testing.T) {
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", UUID: "4f6c2a8e-1d3b-4e5f-a7c9-0b2d4f6a8c1e"},
+				{Slug: "banana", UUID: ""},
+				{Slug: "cherry", UUID: "ccc"},
+				{Slug: "durian", UUID: "4F6C2A8E-1D3B-4E5F-A7C9-0B2D4F6A8C1E"},
+				{Slug: "eggplant", UUID: "{4f6c2a8e-1d3b-4e5f-a7c9-0b2d4f6a8c1e}"},
+				{Slug: "fig", UUID: " 4f6c2a8e-1d3b-4e5f-a7c9-0b2d4f6a8c1e "},
+				{Slug: "grape", UUID: "4f6c2a8e-1d3b-1e5f-a7c9-0b2d4f6a8c1e"},
+				{Slug: "honeydew", UUID: "4f6c2a8e-1d3b-4e5f-a7c9-0b2d4f6a8c1"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"cherry", "honeydew"}, malformedUUIDs(track))
+	assert.Equal(t, []string{"durian", "eggplant", "fig"}, nonCanonicalUUIDs(track))
+	assert.Equal(t, []string{"grape"}, unversionedUUIDs(track))
+
+	for _, slug := range []string{"durian", "eggplant", "fig"} {
+		assert.True(t, fixNonCanonicalUUID(&track.Config, Finding{Slug: slug}), slug)
+	}
+	assert.False(t, fixNonCanonicalUUID(&track.Config, Finding{Slug: "cherry"}))
+	assert.Empty(t, nonCanonicalUUIDs(track))
+	assert.Equal(t, "4f6c2a8e-1d3b-4e5f-a7c9-0b2d4f6a8c1e", track.Config.Exercises[3].UUID)
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/exercism/configlet/track"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackUUIDsAgainstTracksDir(t *testing.T) {
+	originalTracksDir := tracksDir
+	tracksDir = filepath.FromSlash("../fixtures/lint")
+	defer func() { tracksDir = originalTracksDir }()
+
+	rule, ok := findRule("duplicate-track-uuid")
+	if !ok {
+		t.Fatal("duplicate-track-uuid rule is not registered")
+	}
+
+	track := track.Track{
+		ID: "configured-track",
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "aluminum", UUID: "1b0c5e5e-8d3a-4c5f-9e47-0f6a3e1f2b11"},
+				{Slug: "boron", UUID: "bbb"},
+			},
+		},
+	}
+
+	findings := rule.Check(track)
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 conflicting UUID, found %d", len(findings))
+	}
+	assert.Equal(t, "aluminum", findings[0].Slug)
+	assert.Equal(t, "1b0c5e5e-8d3a-4c5f-9e47-0f6a3e1f2b11", findings[0].UUID)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+	assert.Equal(t, "The UUID of the exercise 'aluminum' is already used by the exercise 'aluminum' in the 'valid-track' track. Each exercise UUID must be unique across tracks.\n1b0c5e5e-8d3a-4c5f-9e47-0f6a3e1f2b11", findings[0].Message)
+}
+
+func TestTrackUUIDsAgainstOwnCheckout(t *testing.T) {
+	originalTracksDir := tracksDir
+	tracksDir = filepath.FromSlash("../fixtures/lint")
+	defer func() { tracksDir = originalTracksDir }()
+
+	// The track ID given with --track-id differs from the checkout's directory name.
+	valid, err := track.New(filepath.FromSlash("../fixtures/lint/valid-track"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid.ID = "renamed-track"
+
+	findings := trackUUIDRule{}.Check(valid)
+	if assert.Equal(t, 1, len(findings), "should not report UUIDs as conflicting with the track's own checkout.") {
+		assert.Contains(t, findings[0].Message, "in the 'configured-track' track")
+	}
+}
+
+func TestTrackUUIDsAgainstIndex(t *testing.T) {
+	originalUUIDIndexPath := uuidIndexPath
+	defer func() { uuidIndexPath = originalUUIDIndexPath }()
+
+	dir, err := ioutil.TempDir("", "uuid-index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	uuidIndexPath = filepath.Join(dir, "uuids.json")
+	index := `[
+		{"track_id": "fruit", "slug": "apple", "uuid": "aaa"},
+		{"track_id": "vegetables", "slug": "beet", "uuid": "bbb"},
+		{"track_id": "vegetables", "slug": "carrot", "uuid": "ccc"}
+	]`
+	if err := ioutil.WriteFile(uuidIndexPath, []byte(index), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	track := track.Track{
+		ID: "fruit",
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", UUID: "aaa"},
+				{Slug: "banana", UUID: " bbb "},
+				{Slug: "cherry", UUID: ""},
+			},
+		},
+	}
+
+	findings := trackUUIDRule{}.Check(track)
+	if len(findings) != 1 {
+		t.Fatalf("Expected 1 conflicting UUID, found %d", len(findings))
+	}
+	assert.Equal(t, "banana", findings[0].Slug)
+	assert.Contains(t, findings[0].Message, "'beet' in the 'vegetables' track")
+
+	// The index is only read once.
+	if err := os.Remove(uuidIndexPath); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(trackUUIDRule{}.Check(track)))
+
+	uuidIndexPath = filepath.Join(dir, "missing.json")
+	findings = trackUUIDRule{}.Check(track)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, SeverityError, findings[0].Severity)
+	}
+}
+
+func TestUUIDServiceResponses(t *testing.T) {
+	originalRetries := httpRetries
+	originalBackoff := retryBackoff
+	originalURL := uuidURL
+	httpRetries = 2
+	retryBackoff = time.Millisecond
+	defer func() {
+		httpRetries = originalRetries
+		retryBackoff = originalBackoff
+		uuidURL = originalURL
+	}()
+
+	tests := []struct {
+		desc      string
+		responses []int
+		requests  int
+		severity  Severity
+		findings  int
+	}{
+		{
+			desc:      "should not report anything when the UUIDs are unique.",
+			responses: []int{http.StatusOK},
+			requests:  1,
+		},
+		{
+			desc:      "should report conflicting UUIDs.",
+			responses: []int{http.StatusConflict},
+			requests:  1,
+			severity:  SeverityError,
+			findings:  1,
+		},
+		{
+			desc:      "should retry when the service fails.",
+			responses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusConflict},
+			requests:  3,
+			severity:  SeverityError,
+			findings:  1,
+		},
+		{
+			desc:      "should warn when the service keeps failing.",
+			responses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			requests:  3,
+			severity:  SeverityWarning,
+			findings:  1,
+		},
+		{
+			desc:      "should warn about unexpected responses without retrying.",
+			responses: []int{http.StatusNotFound},
+			requests:  1,
+			severity:  SeverityWarning,
+			findings:  1,
+		},
+	}
+
+	rule, ok := findRule("duplicate-track-uuid")
+	if !ok {
+		t.Fatal("duplicate-track-uuid rule is not registered")
+	}
+
+	track := track.Track{
+		Config: track.Config{
+			Exercises: []track.ExerciseMetadata{
+				{Slug: "apple", UUID: "abc"},
+				{Slug: "banana", UUID: "ccc"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.responses[requests])
+			requests++
+			fmt.Fprintln(w, `{"uuids": ["ccc"]}`)
+		}))
+		uuidURL = ts.URL
+
+		findings := rule.Check(track)
+		ts.Close()
+
+		assert.Equal(t, tt.requests, requests, tt.desc)
+		if assert.Equal(t, tt.findings, len(findings), tt.desc) && tt.findings > 0 {
+			assert.Equal(t, tt.severity, findings[0].Severity, tt.desc)
+		}
+	}
+}
+
+func TestUUIDServiceUnreachable(t *testing.T) {
+	originalRetries := httpRetries
+	originalURL := uuidURL
+	httpRetries = 0
+	defer func() {
+		httpRetries = originalRetries
+		uuidURL = originalURL
+	}()
+
+	ts := httptest.NewServer(http.NotFoundHandler())
+	uuidURL = ts.URL
+	ts.Close()
+
+	rule, ok := findRule("duplicate-track-uuid")
+	if !ok {
+		t.Fatal("duplicate-track-uuid rule is not registered")
+	}
+
+	findings := rule.Check(track.Track{})
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, SeverityWarning, findings[0].Severity)
+		assert.Contains(t, findings[0].Message, "The UUID validation service could not be used")
+	}
+
+	// A negative number of retries makes a single attempt.
+	httpRetries = -1
+	_, err := postWithRetries(uuidURL, []byte("{}"))
+	assert.Error(t, err)
+}
+
+func TestUUIDServiceUnreachableWithConfiguredSeverity(t *testing.T) {
+	originalRetries := httpRetries
+	originalURL := uuidURL
+	originalNoHTTP := noHTTP
+	originalEnabled := enabledRules
+	httpRetries = 0
+	noHTTP = false
+	enabledRules = []string{"duplicate-track-uuid"}
+	defer func() {
+		httpRetries = originalRetries
+		uuidURL = originalURL
+		noHTTP = originalNoHTTP
+		enabledRules = originalEnabled
+	}()
+
+	ts := httptest.NewServer(http.NotFoundHandler())
+	uuidURL = ts.URL
+	ts.Close()
+
+	dir := copyDir(t, filepath.FromSlash("../fixtures/lint/valid-track"))
+	defer os.RemoveAll(dir)
+	cfg := []byte("rules:\n  duplicate-track-uuid: error\n")
+	if err := ioutil.WriteFile(filepath.Join(dir, ".configlet.yml"), cfg, os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The service being unavailable says nothing about the track,
+	// whatever severity the rule is configured with.
+	findings := checkTrack(dir)
+	if assert.Equal(t, 1, len(findings)) {
+		assert.Equal(t, SeverityWarning, findings[0].Severity)
+		assert.Contains(t, findings[0].Message, "The UUID validation service could not be used")
+	}
+}
+
+func TestUUIDServiceURL(t *testing.T) {
+	originalURL := uuidURL
+	originalEnv, hasEnv := os.LookupEnv(uuidURLEnv)
+	defer func() {
+		uuidURL = originalURL
+		if hasEnv {
+			os.Setenv(uuidURLEnv, originalEnv)
+		} else {
+			os.Unsetenv(uuidURLEnv)
+		}
+	}()
+
+	uuidURL = ""
+	os.Unsetenv(uuidURLEnv)
+	assert.Equal(t, UUIDValidationURL, uuidServiceURL())
+
+	os.Setenv(uuidURLEnv, "http://example.com/env")
+	assert.Equal(t, "http://example.com/env", uuidServiceURL())
+
+	uuidURL = "http://example.com/flag"
+	assert.Equal(t, "http://example.com/flag", uuidServiceURL())
+}
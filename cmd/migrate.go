@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/exercism/configlet/track"
+	"github.com/exercism/configlet/ui"
+	"github.com/spf13/cobra"
+)
+
+// migrateDryRun flag displays the changes that migrate-deprecated would make, without making them.
+var migrateDryRun bool
+
+// migrateDeprecatedCmd converts the legacy deprecated list into deprecated flags.
+var migrateDeprecatedCmd = &cobra.Command{
+	Use:   "migrate-deprecated " + pathExample,
+	Short: "Flag deprecated exercises instead of listing them",
+	Long: `The migrate-deprecated command converts the legacy "deprecated" list
+in config.json into "deprecated": true flags on the exercises.
+
+Exercises in the list that have an entry in "exercises" are flagged as
+deprecated. The others get a new entry, with a new UUID, that is flagged
+as deprecated. The list is then removed, and config.json is formatted
+as the fmt command would.
+`,
+	Example: fmt.Sprintf("  %s migrate-deprecated %s --dry-run", binaryName, pathExample),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runMigrateDeprecated(args[0]); err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+	},
+	Args: cobra.ExactArgs(1),
+}
+
+func runMigrateDeprecated(path string) error {
+	configPath := filepath.Join(path, "config.json")
+
+	cfg := track.Config{}
+	if err := cfg.LoadFromFile(configPath); err != nil {
+		return err
+	}
+
+	slugs := migrateDeprecated(&cfg)
+	if len(slugs) == 0 {
+		ui.Print("no deprecated exercises to migrate in", configPath)
+		return nil
+	}
+
+	src, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+	dst, err := cfg.ToJSON()
+	if err != nil {
+		return err
+	}
+	dst = []byte(fmt.Sprintf("%s\n", dst))
+
+	if migrateDryRun {
+		diff, err := diffLines(src, dst)
+		if err != nil {
+			return err
+		}
+		ui.Print(fmt.Sprintf("%s\n\n%s", configPath, diff))
+		return nil
+	}
+
+	if err := writeFile(configPath, dst, false); err != nil {
+		return err
+	}
+	for _, slug := range slugs {
+		ui.Print("flagged as deprecated:", slug)
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(migrateDeprecatedCmd)
+	migrateDeprecatedCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "display the changes, but do not make them.")
+}
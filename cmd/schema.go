@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/exercism/configlet/track"
+	"github.com/exercism/configlet/ui"
+	"github.com/spf13/cobra"
+)
+
+// schemas are the schemas of the track configuration files, by the name given to the schema command.
+var schemas = map[string]func() *track.Schema{
+	"config":      track.ConfigSchema,
+	"maintainers": track.MaintainerConfigSchema,
+}
+
+// schemaCmd prints the JSON Schema of a track configuration file.
+var schemaCmd = &cobra.Command{
+	Use:   "schema [config|maintainers]",
+	Short: "Print the JSON Schema of a track configuration file",
+	Long: `The schema command prints the JSON Schema of config.json or
+config/maintainers.json.
+
+The schema is generated from the types that configlet reads the files into,
+and the lint command validates the files against it.
+`,
+	Example:   fmt.Sprintf("  %s schema config > config.schema.json", binaryName),
+	ValidArgs: []string{"config", "maintainers"},
+	Run: func(cmd *cobra.Command, args []string) {
+		b, err := schemas[args[0]]().ToJSON()
+		if err != nil {
+			ui.PrintError(err.Error())
+			os.Exit(1)
+		}
+		// we don't want any UI formatting prepended to this
+		fmt.Println(string(b))
+	},
+	Args: cobra.ExactValidArgs(1),
+}
+
+func init() {
+	RootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+// defaultTopics is the canonical vocabulary of exercise topics,
+// in the same format as a file given with --topics-path:
+// one topic per line, with blank lines and lines starting with # ignored.
+const defaultTopics = `
+# Control flow
+conditionals
+control_flow_conditionals
+control_flow_if_else_statements
+control_flow_loops
+loops
+recursion
+pattern_matching
+exception_handling
+error_handling
+early_return
+iterators
+generators
+lazy_evaluation
+callbacks
+continuations
+
+# Data types
+booleans
+bitwise_operations
+bit_manipulation
+characters
+chars
+enumerations
+floating_point_numbers
+integers
+big_integers
+rational_numbers
+complex_numbers
+strings
+string_formatting
+text_formatting
+unicode
+null
+optional_values
+maybe
+tuples
+records
+structs
+type_conversion
+type_inference
+generics
+dates
+time
+
+# Data structures
+arrays
+lists
+linked_lists
+stacks
+queues
+trees
+binary_trees
+graphs
+maps
+dictionaries
+hash_tables
+sets
+matrices
+sequences
+vectors
+slices
+immutability
+mutability
+
+# Algorithms and mathematics
+algorithms
+algebra
+arithmetic
+calculation
+combinatorics
+cryptography
+encoding
+filtering
+geometry
+logic
+mathematics
+optimization
+parsing
+prime_numbers
+probability
+randomness
+regular_expressions
+searching
+sorting
+statistics
+transforming
+validation
+equality
+comparison
+interpolation
+
+# Language features and paradigms
+classes
+closures
+concurrency
+parallelism
+asynchronous_programming
+domain_specific_languages
+extension_methods
+functional_programming
+higher_order_functions
+inheritance
+interfaces
+macros
+memory_management
+metaprogramming
+methods
+modules
+namespaces
+object_oriented_programming
+operator_overloading
+polymorphism
+pointers
+properties
+reflection
+refactoring
+scope
+state
+traits
+type_classes
+variables
+visibility
+
+# Input and output
+files
+input_output
+io
+serialization
+json
+networking
+streams
+
+# Practices
+documentation
+performance
+security
+testing
+test_driven_development
+games
+simulation
+`
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// writeFile replaces the file at path with data. The data is written to a
+// temporary file in the same directory, which is then renamed into place, so
+// the file is never left partly written. An existing file keeps its mode, and
+// if backup is true, its previous contents are kept in a copy ending in .orig.
+func writeFile(path string, data []byte, backup bool) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+
+		if backup {
+			orig, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(path+".orig", orig, mode); err != nil {
+				return err
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+
+	// A new file is created.
+	assert.NoError(t, writeFile(path, []byte("one\n"), false))
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "one\n", string(b))
+
+	// An existing file keeps its mode, and is backed up if asked.
+	if err := os.Chmod(path, os.FileMode(0600)); err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, writeFile(path, []byte("two\n"), true))
+
+	b, err = ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "two\n", string(b))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	b, err = ioutil.ReadFile(path + ".orig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "one\n", string(b))
+
+	// No temporary files are left behind.
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(infos))
+}
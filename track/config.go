@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -16,6 +15,34 @@ var (
 	rgxSpaces     = regexp.MustCompile(`[\s-]+`)
 )
 
+// configKeyOrder is the canonical order of the keys in config.json.
+var configKeyOrder = []string{
+	"track_id",
+	"language",
+	"active",
+	"blurb",
+	"gitter",
+	"checklist_issue",
+	"ignore_pattern",
+	"solution_pattern",
+	"test_pattern",
+	"foregone",
+	"exercises",
+	"deprecated",
+}
+
+// exerciseKeyOrder is the canonical order of the keys of an exercise in config.json.
+var exerciseKeyOrder = []string{
+	"slug",
+	"uuid",
+	"core",
+	"auto_approve",
+	"unlocked_by",
+	"difficulty",
+	"topics",
+	"deprecated",
+}
+
 // PatternGroup holds matching patterns defined in an Exercism track configuration.
 type PatternGroup struct {
 	IgnorePattern   string `json:"ignore_pattern,omitempty"`
@@ -27,7 +54,7 @@ type PatternGroup struct {
 // It's listed in the config in the order that the exercise will be
 // delivered by the API.
 type ExerciseMetadata struct {
-	Slug         string   `json:"slug"`
+	Slug         string   `json:"slug" schema:"required"`
 	UUID         string   `json:"uuid"`
 	IsCore       bool     `json:"core"`
 	AutoApprove  bool     `json:"auto_approve,omitempty"`
@@ -35,20 +62,62 @@ type ExerciseMetadata struct {
 	Difficulty   int      `json:"difficulty"`
 	Topics       []string `json:"topics"`
 	IsDeprecated bool     `json:"deprecated,omitempty"`
+	// Extra holds the keys that configlet does not know about,
+	// so that they are kept when the config is formatted.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the exercise metadata, keeping any unknown keys in Extra.
+func (m *ExerciseMetadata) UnmarshalJSON(data []byte) error {
+	type exerciseMetadata ExerciseMetadata
+	if err := json.Unmarshal(data, (*exerciseMetadata)(m)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, exerciseMetadata{})
+	m.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the exercise metadata in the canonical key order,
+// followed by the keys in Extra.
+func (m ExerciseMetadata) MarshalJSON() ([]byte, error) {
+	type exerciseMetadata ExerciseMetadata
+	return marshalOrdered(exerciseMetadata(m), exerciseKeyOrder, m.Extra)
 }
 
 // Config is an Exercism track configuration.
 type Config struct {
 	TrackID        string `json:"track_id,omitempty"`
-	Language       string `json:"language"`
+	Language       string `json:"language" schema:"required"`
 	Active         bool   `json:"active"`
 	Blurb          string `json:"blurb"`
 	Gitter         string `json:"gitter,omitempty"`
 	ChecklistIssue int    `json:"checklist_issue,omitempty"`
 	PatternGroup
 	ForegoneSlugs   []string           `json:"foregone,omitempty"`
-	Exercises       []ExerciseMetadata `json:"exercises"`
+	Exercises       []ExerciseMetadata `json:"exercises" schema:"required"`
 	DeprecatedSlugs []string           `json:"deprecated,omitempty"`
+	// Extra holds the keys that configlet does not know about,
+	// so that they are kept when the config is formatted.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the config, keeping any unknown keys in Extra.
+func (cfg *Config) UnmarshalJSON(data []byte) error {
+	type config Config
+	if err := json.Unmarshal(data, (*config)(cfg)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, config{})
+	cfg.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the config in the canonical key order,
+// followed by the keys in Extra.
+func (cfg Config) MarshalJSON() ([]byte, error) {
+	type config Config
+	return marshalOrdered(config(cfg), configKeyOrder, cfg.Extra)
 }
 
 // NewConfig loads a track configuration file.
@@ -56,6 +125,7 @@ type Config struct {
 // The solution pattern is used to determine if an exercise has a sample solution.
 // The test pattern is used to determine if an exercise has a test suite.
 // The ignore pattern is used to exclude files from the 'exercism fetch' command.
+// The solution and test patterns must be valid regular expressions.
 func NewConfig(path string) (Config, error) {
 	c := Config{
 		PatternGroup: PatternGroup{
@@ -71,6 +141,11 @@ func NewConfig(path string) (Config, error) {
 	}
 	err = json.Unmarshal(bytes, &c)
 	if err != nil {
+		return c, fmt.Errorf("invalid config %s", NewJSONError(path, bytes, err))
+	}
+	// The ignore pattern is not used to find files, but by the Exercism
+	// website, which accepts expressions that Go does not, such as lookaheads.
+	if err := c.PatternGroup.validate(false); err != nil {
 		return c, fmt.Errorf("invalid config %s -- %s", path, err.Error())
 	}
 	return c, nil
@@ -78,13 +153,13 @@ func NewConfig(path string) (Config, error) {
 
 // LoadFromFile loads a config from file given the path to the file.
 func (cfg *Config) LoadFromFile(path string) error {
-	file, err := os.Open(filepath.FromSlash(path))
+	bytes, err := ioutil.ReadFile(filepath.FromSlash(path))
 	if err != nil {
 		return err
 	}
 
-	if err := json.NewDecoder(file).Decode(&cfg); err != nil {
-		return err
+	if err := json.Unmarshal(bytes, cfg); err != nil {
+		return fmt.Errorf("invalid config %s", NewJSONError(path, bytes, err))
 	}
 	return nil
 }
@@ -93,14 +168,61 @@ func (cfg *Config) LoadFromFile(path string) error {
 func (cfg *Config) ToJSON() ([]byte, error) {
 	for _, exercise := range cfg.Exercises {
 		for i, t := range exercise.Topics {
-			exercise.Topics[i] = normalizeTopic(t)
+			exercise.Topics[i] = NormalizeTopic(t)
 		}
 		sort.Strings(exercise.Topics)
 	}
 	return json.MarshalIndent(&cfg, "", "  ")
 }
 
-func normalizeTopic(t string) string {
+// SortExercises orders the exercises with the core exercises first, in their
+// current order, followed by the side exercises unlocked by each of them in
+// turn, then the other side exercises, and finally the deprecated exercises.
+// Exercises keep their current order within each group.
+func (cfg *Config) SortExercises() {
+	deprecated := map[string]bool{}
+	for _, slug := range cfg.DeprecatedSlugs {
+		deprecated[slug] = true
+	}
+
+	var core, side, others, old []ExerciseMetadata
+	unlocks := map[string][]ExerciseMetadata{}
+	for _, exercise := range cfg.Exercises {
+		switch {
+		case exercise.IsDeprecated || deprecated[exercise.Slug]:
+			old = append(old, exercise)
+		case exercise.IsCore:
+			core = append(core, exercise)
+		default:
+			side = append(side, exercise)
+		}
+	}
+
+	isCore := map[string]bool{}
+	for _, exercise := range core {
+		isCore[exercise.Slug] = true
+	}
+	for _, exercise := range side {
+		if exercise.UnlockedBy != nil && isCore[*exercise.UnlockedBy] {
+			unlocks[*exercise.UnlockedBy] = append(unlocks[*exercise.UnlockedBy], exercise)
+		} else {
+			others = append(others, exercise)
+		}
+	}
+
+	exercises := make([]ExerciseMetadata, 0, len(cfg.Exercises))
+	exercises = append(exercises, core...)
+	for _, exercise := range core {
+		exercises = append(exercises, unlocks[exercise.Slug]...)
+	}
+	exercises = append(exercises, others...)
+	exercises = append(exercises, old...)
+	cfg.Exercises = exercises
+}
+
+// NormalizeTopic converts a topic to the form used in config.json:
+// lowercase, without punctuation, and with underscores between words.
+func NormalizeTopic(t string) string {
 	s := strings.ToLower(t)
 	s = rgxFunkyChars.ReplaceAllString(s, "")
 	s = rgxSpaces.ReplaceAllString(s, "_")
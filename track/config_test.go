@@ -140,3 +140,33 @@ func TestSemanticsOfMissingTopics(t *testing.T) {
 	assert.NotNil(t, dstCfg.Exercises[1].Topics)
 	assert.Equal(t, []string{}, dstCfg.Exercises[1].Topics)
 }
+
+func TestSortExercises(t *testing.T) {
+	src := `
+	{
+		"exercises": [
+			{"slug": "e", "unlocked_by": "b"},
+			{"slug": "i"},
+			{"slug": "a", "core": true},
+			{"slug": "f"},
+			{"slug": "g", "deprecated": true},
+			{"slug": "c", "unlocked_by": "a"},
+			{"slug": "b", "core": true},
+			{"slug": "d", "unlocked_by": "a"},
+			{"slug": "h", "unlocked_by": "x"}
+		],
+		"deprecated": ["i"]
+	}
+	`
+	var cfg Config
+	if err := json.NewDecoder(strings.NewReader(src)).Decode(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.SortExercises()
+
+	slugs := []string{}
+	for _, exercise := range cfg.Exercises {
+		slugs = append(slugs, exercise.Slug)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f", "h", "i", "g"}, slugs)
+}
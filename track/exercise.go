@@ -2,6 +2,7 @@ package track
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -10,35 +11,199 @@ import (
 
 // Exercise is an implementation of an Exercism exercise.
 type Exercise struct {
-	Slug          string
-	ReadmePath    string
-	SolutionPath  string
-	TestSuitePath string
+	Slug       string
+	ReadmePath string
+	// SolutionPaths are all of the files matched by the solution pattern,
+	// as there may be more than one for multi-file exercises.
+	SolutionPaths []string
+	// TestSuitePaths are all of the files matched by the test pattern.
+	TestSuitePaths []string
+	// Files are the paths of all of the files in the exercise,
+	// relative to the exercise directory, with forward slashes.
+	Files []string
+	// Config is the exercise's .meta/config.json, or nil if it has none.
+	Config *ExerciseConfig
+	// FileErrors are the files and directories that could not be read.
+	FileErrors []FileError
+	// ConfigError is the error from reading the exercise's .meta/config.json,
+	// if it could not be read. The patterns are used instead.
+	ConfigError error
+}
+
+// Option changes how a track and its exercises are loaded.
+type Option func(*options)
+
+type options struct {
+	followSymlinks bool
+}
+
+// FollowSymlinks sets whether symbolic links to directories in exercise
+// directories are followed. By default they are skipped. Symbolic links to
+// files are always listed as files.
+func FollowSymlinks(follow bool) Option {
+	return func(o *options) {
+		o.followSymlinks = follow
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
 }
 
 // NewExercise loads an exercise.
-func NewExercise(root string, pg PatternGroup) (Exercise, error) {
+// The example solution and test suite are the files listed in the exercise's
+// .meta/config.json, if it has one, or else the files matched by the patterns.
+// Files that cannot be read are recorded in FileErrors, and a .meta/config.json
+// that cannot be read in ConfigError, rather than stopping the exercise from
+// being loaded.
+func NewExercise(root string, pg PatternGroup, opts ...Option) (Exercise, error) {
 	ex := Exercise{
 		Slug: filepath.Base(root),
 	}
 
-	err := setPath(root, pg.SolutionPattern, &ex.SolutionPath)
+	cfg, err := NewExerciseConfig(root)
 	if err != nil {
-		return ex, err
+		ex.ConfigError = err
+	}
+
+	w := newWalker(root, newOptions(opts))
+	w.walk(root)
+	ex.FileErrors = w.errors
+
+	if cfg != nil {
+		ex.Config = cfg
+		ex.SolutionPaths = fromSlash(cfg.Files.Example)
+		ex.TestSuitePaths = fromSlash(cfg.Files.Test)
+	} else {
+		err = setPaths(root, pg.SolutionPattern, w.paths, &ex.SolutionPaths)
+		if err != nil {
+			return ex, err
+		}
+
+		err = setPaths(root, pg.TestPattern, w.paths, &ex.TestSuitePaths)
+		if err != nil {
+			return ex, err
+		}
 	}
 
-	err = setPath(root, pg.TestPattern, &ex.TestSuitePath)
+	var readmes []string
+	err = setPaths(root, "README\\.md", w.paths, &readmes)
 	if err != nil {
 		return ex, err
 	}
+	if len(readmes) > 0 {
+		ex.ReadmePath = readmes[len(readmes)-1]
+	}
+
+	ex.Files = []string{}
+	for _, path := range w.paths {
+		ex.Files = append(ex.Files, w.rel(path))
+	}
+	return ex, nil
+}
+
+// FileError is a file or directory in an exercise that could not be read.
+type FileError struct {
+	// Path is relative to the exercise directory, with forward slashes.
+	Path string
+	Err  error
+}
+
+func (e FileError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+// walker finds the files in an exercise directory, in lexical order,
+// skipping symbolic links unless they are to be followed.
+type walker struct {
+	root   string
+	follow bool
+	paths  []string
+	errors []FileError
+	// visited holds the real paths of the directories that were reached
+	// through symbolic links, so that loops are only followed once.
+	visited map[string]bool
+}
+
+func newWalker(root string, o options) *walker {
+	w := &walker{root: root, follow: o.followSymlinks, visited: map[string]bool{}}
+	if real, err := filepath.EvalSymlinks(root); err == nil {
+		w.visited[real] = true
+	}
+	return w
+}
+
+// rel returns the path relative to the exercise directory, with forward slashes.
+func (w *walker) rel(path string) string {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (w *walker) fail(path string, err error) {
+	w.errors = append(w.errors, FileError{Path: w.rel(path), Err: err})
+}
+
+func (w *walker) walk(dir string) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		w.fail(dir, err)
+		return
+	}
+
+	for _, info := range infos {
+		path := filepath.Join(dir, info.Name())
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			target, err := os.Stat(path)
+			if err != nil {
+				w.fail(path, err)
+				continue
+			}
+			if target.IsDir() {
+				if !w.follow {
+					continue
+				}
+				real, err := filepath.EvalSymlinks(path)
+				if err != nil {
+					w.fail(path, err)
+					continue
+				}
+				if w.visited[real] {
+					continue
+				}
+				w.visited[real] = true
+			}
+			info = target
+		}
+
+		if info.IsDir() {
+			w.walk(path)
+			continue
+		}
+		w.paths = append(w.paths, path)
+	}
+}
 
-	err = setPath(root, "README\\.md", &ex.ReadmePath)
-	return ex, err
+// fromSlash converts the listed paths to use the OS separator,
+// as the paths found by the patterns do.
+func fromSlash(paths []string) []string {
+	converted := make([]string, len(paths))
+	for i, path := range paths {
+		converted[i] = filepath.FromSlash(path)
+	}
+	return converted
 }
 
-// setPath sets the value of field to the file path matched by pattern.
-// The resulting file path, if matched, will be relative to root.
-func setPath(root, pattern string, field *string) error {
+// setPaths sets the value of field to the file paths matched by pattern,
+// in lexical order. The resulting file paths will be relative to root.
+func setPaths(root, pattern string, paths []string, field *[]string) error {
 
 	if pattern == "" {
 		return nil
@@ -49,19 +214,13 @@ func setPath(root, pattern string, field *string) error {
 		return err
 	}
 
-	walkFn := func(path string, info os.FileInfo, err error) error {
-		if info.IsDir() {
-			return nil
-		}
-
+	prefix := fmt.Sprintf("%s%s", root, string(filepath.Separator))
+	for _, path := range paths {
 		if rgx.Match([]byte(path)) {
-			prefix := fmt.Sprintf("%s%s", root, string(filepath.Separator))
-			*field = strings.Replace(path, prefix, "", 1)
+			*field = append(*field, strings.Replace(path, prefix, "", 1))
 		}
-		return nil
 	}
-
-	return filepath.Walk(root, walkFn)
+	return nil
 }
 
 // HasReadme checks that an exercise has a README.
@@ -71,10 +230,10 @@ func (ex Exercise) HasReadme() bool {
 
 // HasTestSuite checks that an exercise has a test suite.
 func (ex Exercise) HasTestSuite() bool {
-	return ex.TestSuitePath != ""
+	return len(ex.TestSuitePaths) > 0
 }
 
 // IsValid checks that an exercise has a sample solution.
 func (ex Exercise) IsValid() bool {
-	return ex.SolutionPath != ""
+	return len(ex.SolutionPaths) > 0
 }
@@ -0,0 +1,129 @@
+package track
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// exerciseConfigKeyOrder is the canonical order of the keys in an exercise's .meta/config.json.
+var exerciseConfigKeyOrder = []string{
+	"files",
+}
+
+// exerciseFilesKeyOrder is the canonical order of the keys of the files in an exercise's .meta/config.json.
+var exerciseFilesKeyOrder = []string{
+	"solution",
+	"test",
+	"example",
+	"editor",
+}
+
+// ExerciseConfig is the optional configuration of an exercise,
+// found in its .meta/config.json file.
+type ExerciseConfig struct {
+	Files ExerciseFiles `json:"files"`
+	// Extra holds the keys that configlet does not know about,
+	// so that they are kept when the config is formatted.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the exercise config, keeping any unknown keys in Extra.
+func (ec *ExerciseConfig) UnmarshalJSON(data []byte) error {
+	type exerciseConfig ExerciseConfig
+	if err := json.Unmarshal(data, (*exerciseConfig)(ec)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, exerciseConfig{})
+	ec.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the exercise config in the canonical key order,
+// followed by the keys in Extra.
+func (ec ExerciseConfig) MarshalJSON() ([]byte, error) {
+	type exerciseConfig ExerciseConfig
+	return marshalOrdered(exerciseConfig(ec), exerciseConfigKeyOrder, ec.Extra)
+}
+
+// LoadFromFile loads an exercise config from file given the path to the file.
+func (ec *ExerciseConfig) LoadFromFile(path string) error {
+	bytes, err := ioutil.ReadFile(filepath.FromSlash(path))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bytes, ec); err != nil {
+		return fmt.Errorf("invalid exercise config %s", NewJSONError(path, bytes, err))
+	}
+	return nil
+}
+
+// ToJSON marshals the exercise config to normalized JSON.
+func (ec ExerciseConfig) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(&ec, "", "  ")
+}
+
+// ExerciseFiles lists the files of an exercise, relative to its directory.
+// When they are listed, the solution and test patterns are not used.
+type ExerciseFiles struct {
+	// Solution are the files that the student edits.
+	Solution []string `json:"solution"`
+	// Test are the files of the test suite.
+	Test []string `json:"test"`
+	// Example are the files of the example solution.
+	Example []string `json:"example"`
+	// Editor are additional files that the student reads, but does not edit.
+	Editor []string `json:"editor,omitempty"`
+	// Extra holds the keys that configlet does not know about,
+	// so that they are kept when the config is formatted.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the exercise files, keeping any unknown keys in Extra.
+func (f *ExerciseFiles) UnmarshalJSON(data []byte) error {
+	type exerciseFiles ExerciseFiles
+	if err := json.Unmarshal(data, (*exerciseFiles)(f)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, exerciseFiles{})
+	f.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the exercise files in the canonical key order,
+// followed by the keys in Extra.
+func (f ExerciseFiles) MarshalJSON() ([]byte, error) {
+	type exerciseFiles ExerciseFiles
+	return marshalOrdered(exerciseFiles(f), exerciseFilesKeyOrder, f.Extra)
+}
+
+// All returns every file that is listed.
+func (f ExerciseFiles) All() []string {
+	files := []string{}
+	for _, paths := range [][]string{f.Solution, f.Test, f.Example, f.Editor} {
+		files = append(files, paths...)
+	}
+	return files
+}
+
+// NewExerciseConfig reads the exercise config file in the exercise directory,
+// if present. It returns nil if the exercise does not have one.
+func NewExerciseConfig(root string) (*ExerciseConfig, error) {
+	path := filepath.Join(root, ".meta", "config.json")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	ec := &ExerciseConfig{}
+	if err := json.Unmarshal(bytes, ec); err != nil {
+		return nil, fmt.Errorf("invalid exercise config %w", NewJSONError(path, bytes, err))
+	}
+	return ec, nil
+}
@@ -1,6 +1,9 @@
 package track
 
 import (
+	"errors"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -18,32 +21,37 @@ func TestExerciseSlug(t *testing.T) {
 func TestExerciseSolutionPaths(t *testing.T) {
 	tests := []struct {
 		PatternGroup
-		path string
+		paths []string
 	}{
 		{
 			// It finds files in the root of the exercise directory.
 			PatternGroup{SolutionPattern: "[Ee]xample"},
-			"example.ext",
+			[]string{"example.ext"},
 		},
 		{
 			// It finds files in a subdirectory.
-			PatternGroup{SolutionPattern: "solution"},
-			"subdir/solution.ext",
+			PatternGroup{SolutionPattern: "subdir.solution"},
+			[]string{"subdir/solution.ext"},
 		},
 		{
 			// It only matches files, not directories.
 			PatternGroup{SolutionPattern: "subdir"},
-			"subdir/solution.ext",
+			[]string{"subdir/.secret-solution.ext", "subdir/solution.ext"},
 		},
 		// It finds hidden files.
 		{
 			PatternGroup{SolutionPattern: "secret-solution"},
-			"subdir/.secret-solution.ext",
+			[]string{"subdir/.secret-solution.ext"},
 		},
 		// it finds files in hidden directories
 		{
 			PatternGroup{SolutionPattern: "hidden.file\\.ext"},
-			".hidden/file.ext",
+			[]string{".hidden/file.ext"},
+		},
+		// It finds all of the matching files.
+		{
+			PatternGroup{SolutionPattern: "solution"},
+			[]string{"subdir/.secret-solution.ext", "subdir/solution.ext"},
 		},
 	}
 
@@ -53,23 +61,28 @@ func TestExerciseSolutionPaths(t *testing.T) {
 		ex, err := NewExercise(path, test.PatternGroup)
 		assert.NoError(t, err)
 
-		assert.Equal(t, test.path, ex.SolutionPath)
+		assert.Equal(t, test.paths, ex.SolutionPaths)
 	}
 }
 func TestExerciseTestSuitePaths(t *testing.T) {
 	tests := []struct {
 		PatternGroup
-		path string
+		paths []string
 	}{
 		{
 			// It finds files in the root of the exercise directory.
 			PatternGroup{TestPattern: "(?i)test"},
-			"fake_test.ext",
+			[]string{"fake_test.ext"},
 		},
 		{
 			// It finds files in a subdirectory.
 			PatternGroup{TestPattern: "specs"},
-			"specs/file.ext",
+			[]string{"specs/file.ext"},
+		},
+		{
+			// It finds all of the matching files.
+			PatternGroup{TestPattern: "specs|_test"},
+			[]string{"fake_test.ext", "specs/file.ext"},
 		},
 	}
 
@@ -79,6 +92,152 @@ func TestExerciseTestSuitePaths(t *testing.T) {
 		ex, err := NewExercise(path, test.PatternGroup)
 		assert.NoError(t, err)
 
-		assert.Equal(t, test.path, ex.TestSuitePath)
+		assert.Equal(t, test.paths, ex.TestSuitePaths)
+	}
+}
+
+func TestExerciseFiles(t *testing.T) {
+	path := filepath.FromSlash("../fixtures/fake-exercise")
+
+	ex, err := NewExercise(path, PatternGroup{})
+	assert.NoError(t, err)
+
+	expected := []string{
+		".hidden/file.ext",
+		"example.ext",
+		"fake_test.ext",
+		"specs/file.ext",
+		"subdir/.secret-solution.ext",
+		"subdir/solution.ext",
+	}
+	assert.Equal(t, expected, ex.Files)
+}
+
+func TestExerciseConfig(t *testing.T) {
+	path := filepath.FromSlash("../fixtures/meta-exercise")
+	pg := PatternGroup{SolutionPattern: "[Ee]xample", TestPattern: "(?i)test"}
+
+	ex, err := NewExercise(path, pg)
+	assert.NoError(t, err)
+
+	if assert.NotNil(t, ex.Config) {
+		assert.Equal(t, []string{"src/meta_exercise.ext"}, ex.Config.Files.Solution)
+		assert.Equal(t, []string{"src/helper.ext"}, ex.Config.Files.Editor)
+	}
+	// The listed files are preferred over the patterns.
+	assert.Equal(t, []string{filepath.FromSlash(".meta/src/example.ext")}, ex.SolutionPaths)
+	assert.Equal(t, []string{filepath.FromSlash("tests/meta_exercise_test.ext")}, ex.TestSuitePaths)
+
+	ex, err = NewExercise(filepath.FromSlash("../fixtures/fake-exercise"), pg)
+	assert.NoError(t, err)
+	assert.Nil(t, ex.Config)
+}
+
+func TestInvalidExerciseConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "invalid-exercise-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(filepath.Join(dir, ".meta"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, ".meta", "config.json"), []byte(`{"files": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "example.ext"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The error is recorded, and the patterns are used instead.
+	ex, err := NewExercise(dir, PatternGroup{SolutionPattern: "[Ee]xample"})
+	assert.NoError(t, err)
+	assert.Nil(t, ex.Config)
+	assert.Equal(t, []string{"example.ext"}, ex.SolutionPaths)
+	if assert.Error(t, ex.ConfigError) {
+		assert.Contains(t, ex.ConfigError.Error(), "invalid exercise config")
+		var jsonErr *JSONError
+		if assert.True(t, errors.As(ex.ConfigError, &jsonErr)) {
+			assert.Equal(t, 1, jsonErr.Line)
+			assert.Equal(t, "files", jsonErr.Field)
+		}
+	}
+}
+
+func TestExerciseSymlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "symlinks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{"example.ext", filepath.Join("sub", "file.ext"), filepath.Join("sub", "shared.md")} {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"README.md":  filepath.Join("sub", "shared.md"),
+		"linked.ext": "example.ext",
+		"broken.ext": "missing.ext",
+		"linkdir":    "sub",
+		"loop":       ".",
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Skipf("symlinks are not supported: %s", err)
+		}
+	}
+
+	ex, err := NewExercise(dir, PatternGroup{SolutionPattern: "[Ee]xample"}, FollowSymlinks(true))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "example.ext", "linkdir/file.ext", "linkdir/shared.md", "linked.ext", "sub/file.ext", "sub/shared.md"}, ex.Files)
+	if assert.Equal(t, 1, len(ex.FileErrors)) {
+		assert.Equal(t, "broken.ext", ex.FileErrors[0].Path)
+	}
+
+	// Links to files are listed by default, but links to directories are skipped.
+	for _, opts := range [][]Option{nil, {FollowSymlinks(false)}} {
+		ex, err := NewExercise(dir, PatternGroup{SolutionPattern: "[Ee]xample"}, opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"README.md", "example.ext", "linked.ext", "sub/file.ext", "sub/shared.md"}, ex.Files)
+		assert.Equal(t, []string{"example.ext"}, ex.SolutionPaths)
+		assert.True(t, ex.HasReadme(), "should count a linked README.")
+		if assert.Equal(t, 1, len(ex.FileErrors)) {
+			assert.Equal(t, "broken.ext", ex.FileErrors[0].Path)
+		}
+	}
+}
+
+func TestExerciseUnreadableDirectory(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for root")
+	}
+
+	dir, err := ioutil.TempDir("", "unreadable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "example.ext"), []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	locked := filepath.Join(dir, "locked")
+	if err := os.Mkdir(locked, 0000); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(locked, 0755)
+
+	ex, err := NewExercise(dir, PatternGroup{SolutionPattern: "[Ee]xample"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"example.ext"}, ex.Files)
+	if assert.Equal(t, 1, len(ex.FileErrors)) {
+		assert.Equal(t, "locked", ex.FileErrors[0].Path)
 	}
 }
@@ -0,0 +1,112 @@
+package track
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// extraFields returns the members of the JSON object in data that do not
+// correspond to a field of v, which must be a struct. Keys are matched
+// case-insensitively, as encoding/json does. It returns nil if there are none.
+func extraFields(data []byte, v interface{}) (map[string]json.RawMessage, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	known := jsonKeys(reflect.TypeOf(v))
+
+	var extra map[string]json.RawMessage
+	for key, value := range members {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+		extra[key] = value
+	}
+	return extra, nil
+}
+
+// jsonKeys returns the lowercased JSON keys of the fields of the struct type,
+// including those of embedded structs.
+func jsonKeys(t reflect.Type) map[string]bool {
+	keys := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for key := range jsonKeys(field.Type) {
+				keys[key] = true
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		keys[strings.ToLower(name)] = true
+	}
+	return keys
+}
+
+// marshalOrdered marshals v, which must marshal to a JSON object, with its
+// members in the given key order. The extra members, and any others that are
+// not in the order, follow in alphabetical order of their keys.
+func marshalOrdered(v interface{}, order []string, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := members[key]; !ok {
+			members[key] = value
+		}
+	}
+
+	keys := []string{}
+	ordered := map[string]bool{}
+	for _, key := range order {
+		ordered[key] = true
+		if _, ok := members[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	rest := []string{}
+	for key := range members {
+		if !ordered[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	keys = append(keys, rest...)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(members[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
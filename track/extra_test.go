@@ -0,0 +1,113 @@
+package track
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigUnknownKeys(t *testing.T) {
+	src := `{
+		"version": 3,
+		"exercises": [{"status": "beta", "slug": "one", "Topics": ["strings"]}],
+		"language": "Numbers",
+		"online_editor": {"indent_style": "space"},
+		"solution_pattern": "example"
+	}`
+
+	cfg := Config{}
+	if err := json.Unmarshal([]byte(src), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(cfg.Extra))
+	assert.Equal(t, "3", string(cfg.Extra["version"]))
+	assert.Equal(t, "example", cfg.SolutionPattern, "should not treat keys of embedded structs as unknown.")
+	assert.Equal(t, []string{"strings"}, cfg.Exercises[0].Topics)
+	assert.Equal(t, map[string]json.RawMessage{"status": json.RawMessage(`"beta"`)}, cfg.Exercises[0].Extra)
+
+	dst, err := json.Marshal(cfg.Exercises[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `{"slug":"one","uuid":"","core":false,"unlocked_by":null,"difficulty":0,"topics":["strings"],"status":"beta"}`, string(dst))
+
+	dst, err = json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Regexp(t, `"exercises":\[.*\],"online_editor":\{"indent_style":"space"\},"version":3\}$`, string(dst))
+}
+
+func TestMaintainerConfigUnknownKeys(t *testing.T) {
+	src := `{"team": "numbers", "maintainers": [{"github_username": "alice", "pronouns": "they/them"}]}`
+
+	mc := MaintainerConfig{}
+	if err := json.Unmarshal([]byte(src), &mc); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `"numbers"`, string(mc.Extra["team"]))
+	assert.Equal(t, `"they/them"`, string(mc.Maintainers[0].Extra["pronouns"]))
+
+	dst, err := json.Marshal(MaintainerConfig{Extra: mc.Extra})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `{"docs_url":"","maintainers":null,"team":"numbers"}`, string(dst))
+}
+
+func TestMarshalOrdered(t *testing.T) {
+	v := struct {
+		B int `json:"b"`
+		A int `json:"a"`
+		C int `json:"c,omitempty"`
+	}{B: 2, A: 1}
+	extra := map[string]json.RawMessage{"e": json.RawMessage("5"), "d": json.RawMessage("4")}
+
+	dst, err := marshalOrdered(v, []string{"a", "c", "b"}, extra)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":2,"d":4,"e":5}`, string(dst))
+
+	dst, err = marshalOrdered(struct{}{}, nil, extra)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"d":4,"e":5}`, string(dst))
+}
+
+func TestKeyOrders(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		order []string
+	}{
+		{Config{}, configKeyOrder},
+		{ExerciseMetadata{}, exerciseKeyOrder},
+		{MaintainerConfig{}, maintainerConfigKeyOrder},
+		{Maintainer{}, maintainerKeyOrder},
+		{ExerciseConfig{}, exerciseConfigKeyOrder},
+		{ExerciseFiles{}, exerciseFilesKeyOrder},
+	}
+
+	for _, tt := range tests {
+		keys := map[string]bool{}
+		for _, key := range tt.order {
+			keys[key] = true
+		}
+		assert.Equal(t, jsonKeys(reflect.TypeOf(tt.v)), keys, "should order every key of %T.", tt.v)
+	}
+}
+
+func TestExerciseConfigUnknownKeys(t *testing.T) {
+	src := `{"files": {"test": ["one_test.ext"], "invalidator": ["build.ext"]}, "authors": ["alice"]}`
+
+	ec := ExerciseConfig{}
+	if err := json.Unmarshal([]byte(src), &ec); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"one_test.ext"}, ec.Files.Test)
+
+	dst, err := json.Marshal(ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `{"files":{"solution":null,"test":["one_test.ext"],"example":null,"invalidator":["build.ext"]},"authors":["alice"]}`, string(dst))
+}
@@ -0,0 +1,348 @@
+package track
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// snippetWidth is the number of characters shown on either side of
+// the position of an error in a long line.
+const snippetWidth = 40
+
+// JSONError is an error from decoding a JSON file,
+// with the position in the file where it was found.
+type JSONError struct {
+	// Path is the path of the file.
+	Path string
+	// Line and Column are the position of the error, starting at 1,
+	// or 0 if it is not known.
+	Line   int
+	Column int
+	// Field is the path of the field with the error, such as
+	// exercises[12].difficulty, or empty if it is not known.
+	Field string
+	// Snippet is the line with the error, with a caret under the position of the error.
+	Snippet string
+	// Err is the error from decoding the file.
+	Err error
+}
+
+func (e *JSONError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s -- %s", e.Path, e.Err)
+	}
+
+	msg := e.Err.Error()
+	if terr, ok := e.Err.(*json.UnmarshalTypeError); ok {
+		msg = fmt.Sprintf("expected %s, found %s", jsonKind(terr.Type), withArticle(terr.Value))
+	}
+	if e.Field != "" {
+		msg = fmt.Sprintf("%s: %s", e.Field, msg)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s\n%s", e.Path, e.Line, e.Column, msg, e.Snippet)
+}
+
+func (e *JSONError) Unwrap() error {
+	return e.Err
+}
+
+// NewJSONError describes the error from decoding the JSON data in the file
+// at path, finding where in the data it occurred.
+func NewJSONError(path string, data []byte, err error) *JSONError {
+	e := &JSONError{Path: path, Err: err}
+
+	var offset int64
+	switch err := err.(type) {
+	case *json.SyntaxError:
+		// The offset is just past the byte where the error was found.
+		offset = err.Offset - 1
+		e.Field = newJSONScanner(data).pathAt(offset)
+	case *json.UnmarshalTypeError:
+		token, ok := newJSONScanner(data).find(err)
+		if !ok {
+			return e
+		}
+		offset = token.start
+		e.Field = token.path
+	default:
+		return e
+	}
+
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	e.Line, e.Column, e.Snippet = position(data, int(offset))
+	return e
+}
+
+// position returns the line and column of the byte at offset in data,
+// and the line with a caret under it.
+func position(data []byte, offset int) (int, int, string) {
+	start := bytes.LastIndexByte(data[:offset], '\n') + 1
+	end := bytes.IndexByte(data[offset:], '\n')
+	if end < 0 {
+		end = len(data)
+	} else {
+		end += offset
+	}
+
+	line := bytes.Count(data[:offset], []byte("\n")) + 1
+	before := []rune(string(data[start:offset]))
+	after := []rune(strings.TrimRight(string(data[offset:end]), "\r"))
+	column := len(before) + 1
+
+	prefix, suffix := "", ""
+	if len(before) > snippetWidth {
+		before = before[len(before)-snippetWidth:]
+		prefix = "..."
+	}
+	if len(after) > snippetWidth {
+		after = after[:snippetWidth]
+		suffix = "..."
+	}
+
+	// Keep the tabs in the indentation of the caret, so that it lines up.
+	indent := []rune(prefix + string(before))
+	for i, r := range indent {
+		if r != '\t' {
+			indent[i] = ' '
+		}
+	}
+	snippet := fmt.Sprintf("    %s%s%s%s\n    %s^", prefix, string(before), string(after), suffix, string(indent))
+	return line, column, snippet
+}
+
+// jsonKind describes the JSON values that decode into the type.
+func jsonKind(t reflect.Type) string {
+	if t == nil {
+		return "a value"
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	}
+	return t.String()
+}
+
+// withArticle prefixes the noun with an indefinite article.
+func withArticle(noun string) string {
+	if noun == "null" {
+		return noun
+	}
+	if noun != "" && strings.ContainsRune("aeiou", rune(noun[0])) {
+		return "an " + noun
+	}
+	return "a " + noun
+}
+
+// jsonToken is a value in a JSON document.
+type jsonToken struct {
+	// start and end are the offsets of the first byte of the value,
+	// or of its opening delimiter, and of the byte after it.
+	start, end int64
+	// path is the path of the field holding the value.
+	path string
+	// containers are the offsets of the objects and arrays that enclose the value.
+	containers []int64
+}
+
+// jsonFrame is an object or array being scanned.
+type jsonFrame struct {
+	array bool
+	start int64
+	// index is the index of the current element of an array.
+	index int
+	// key is the key of the current member of an object,
+	// and expectKey is true when the next token is a key.
+	key       string
+	expectKey bool
+}
+
+// jsonScanner finds the values in a JSON document and the fields that hold them.
+type jsonScanner struct {
+	data   []byte
+	frames []jsonFrame
+}
+
+func newJSONScanner(data []byte) *jsonScanner {
+	return &jsonScanner{data: data}
+}
+
+// scan calls fn with each value in the document, until fn returns false,
+// or the end of the document or a syntax error is reached.
+func (s *jsonScanner) scan(fn func(jsonToken) bool) {
+	dec := json.NewDecoder(bytes.NewReader(s.data))
+	dec.UseNumber()
+
+	for {
+		start := s.skipSeparators(dec.InputOffset())
+		tok, err := dec.Token()
+		if err != nil {
+			return
+		}
+		end := dec.InputOffset()
+
+		n := len(s.frames)
+		if key, ok := tok.(string); ok && n > 0 && s.frames[n-1].expectKey {
+			s.frames[n-1].key = key
+			s.frames[n-1].expectKey = false
+			continue
+		}
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			s.frames = s.frames[:n-1]
+			s.endValue()
+			continue
+		}
+
+		if n > 0 && s.frames[n-1].array {
+			s.frames[n-1].index++
+		}
+		containers := make([]int64, len(s.frames))
+		for i, f := range s.frames {
+			containers[i] = f.start
+		}
+		if !fn(jsonToken{start: start, end: end, path: s.path(), containers: containers}) {
+			return
+		}
+
+		if d, ok := tok.(json.Delim); ok {
+			s.frames = append(s.frames, jsonFrame{array: d == '[', start: start, index: -1, expectKey: d == '{'})
+			continue
+		}
+		s.endValue()
+	}
+}
+
+// endValue records that the value of the current member of an object is complete.
+func (s *jsonScanner) endValue() {
+	if n := len(s.frames); n > 0 && !s.frames[n-1].array {
+		s.frames[n-1].expectKey = true
+	}
+}
+
+// skipSeparators returns the offset of the next token at or after offset.
+func (s *jsonScanner) skipSeparators(offset int64) int64 {
+	for offset < int64(len(s.data)) && strings.IndexByte(" \t\r\n:,", s.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// path returns the path of the field holding the current value.
+func (s *jsonScanner) path() string {
+	var sb strings.Builder
+	for i, f := range s.frames {
+		switch {
+		case f.array && f.index >= 0:
+			fmt.Fprintf(&sb, "[%d]", f.index)
+		case !f.array && f.key != "" && !(f.expectKey && i == len(s.frames)-1):
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(f.key)
+		}
+	}
+	return sb.String()
+}
+
+// pathAt returns the path of the field being scanned at offset.
+func (s *jsonScanner) pathAt(offset int64) string {
+	s.scan(func(t jsonToken) bool {
+		return t.end <= offset
+	})
+	return s.path()
+}
+
+// find returns the value that caused the type error. Objects with their own
+// UnmarshalJSON method report fields and offsets from their own start, so the
+// field is matched against the end of the path, and the offset against the
+// start of each enclosing object and array.
+func (s *jsonScanner) find(err *json.UnmarshalTypeError) (jsonToken, bool) {
+	field := scannerField(err.Field)
+
+	var found jsonToken
+	ok := false
+	s.scan(func(t jsonToken) bool {
+		if field == "" {
+			// A type error for a value with its own UnmarshalJSON method has no field,
+			// and its offset is from the start of the value, which may be an array
+			// element, the value of a key or the document itself. The value is
+			// matched by its kind and length.
+			if t.end-t.start == err.Offset && strings.HasPrefix(err.Value, s.kind(t)) {
+				found, ok = t, true
+				return false
+			}
+			return true
+		}
+		if t.path != field && !strings.HasSuffix(t.path, "."+field) &&
+			!(field[0] == '[' && strings.HasSuffix(t.path, field)) {
+			return true
+		}
+		for _, start := range append([]int64{0}, t.containers...) {
+			if t.end-start == err.Offset {
+				found, ok = t, true
+				return false
+			}
+		}
+		return true
+	})
+	return found, ok
+}
+
+// kind returns the JSON type of the value, as named by type errors.
+func (s *jsonScanner) kind(t jsonToken) string {
+	switch s.data[t.start] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+// scannerField converts the field of a type error, such as topics.0,
+// to the form of the paths found by the scanner, such as topics[0].
+func scannerField(field string) string {
+	if field == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, part := range strings.Split(field, ".") {
+		if _, err := strconv.Atoi(part); err == nil {
+			fmt.Fprintf(&sb, "[%s]", part)
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
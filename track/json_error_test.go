@@ -0,0 +1,131 @@
+package track
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJSONError(t *testing.T) {
+	tests := []struct {
+		desc    string
+		src     string
+		line    int
+		column  int
+		field   string
+		snippet string
+	}{
+		{
+			desc:    "syntax error",
+			src:     "{\n  \"language\": \"Numbers\",\n  \"exercises\": [\n    {\"slug\": \"one\",}\n  ]\n}",
+			line:    4,
+			column:  20,
+			field:   "exercises[0]",
+			snippet: "        {\"slug\": \"one\",}\n                       ^",
+		},
+		{
+			desc:    "type error in a nested object",
+			src:     "{\n  \"exercises\": [\n    {\"slug\": \"one\"},\n    {\"slug\": \"two\", \"difficulty\": \"hard\"}\n  ]\n}",
+			line:    4,
+			column:  35,
+			field:   "exercises[1].difficulty",
+			snippet: "        {\"slug\": \"two\", \"difficulty\": \"hard\"}\n                                      ^",
+		},
+		{
+			desc:    "type error at the top level",
+			src:     "{\n\t\"active\": \"yes\"\n}",
+			line:    2,
+			column:  12,
+			field:   "active",
+			snippet: "    \t\"active\": \"yes\"\n    \t          ^",
+		},
+		{
+			desc:    "type error in an object",
+			src:     `{"exercises": [{"slug": "one", "topics": {"strings": true}}]}`,
+			line:    1,
+			column:  42,
+			field:   "exercises[0].topics",
+			snippet: "    ...\"exercises\": [{\"slug\": \"one\", \"topics\": {\"strings\": true}}]}\n                                               ^",
+		},
+		{
+			desc:    "type error in an array element",
+			src:     `{"exercises": [{"slug": "one", "topics": [3]}]}`,
+			line:    1,
+			column:  43,
+			field:   "exercises[0].topics[0]",
+			snippet: "    ...exercises\": [{\"slug\": \"one\", \"topics\": [3]}]}\n                                               ^",
+		},
+		{
+			desc:    "type error in a later array element",
+			src:     "{\n  \"exercises\": [\n    {\"slug\": \"one\", \"topics\": [\"strings\", 3]}\n  ]\n}",
+			line:    3,
+			column:  43,
+			field:   "exercises[0].topics[1]",
+			snippet: "    ...  {\"slug\": \"one\", \"topics\": [\"strings\", 3]}\n                                               ^",
+		},
+		{
+			desc:    "type error in an array element with its own UnmarshalJSON method",
+			src:     `{"exercises":[1]}`,
+			line:    1,
+			column:  15,
+			field:   "exercises[0]",
+			snippet: "    {\"exercises\":[1]}\n                  ^",
+		},
+		{
+			desc:    "type error in a later array element with its own UnmarshalJSON method",
+			src:     "{\n  \"foregone\": [\"one\"],\n  \"exercises\": [{\"slug\": \"two\"}, \"three\"]\n}",
+			line:    3,
+			column:  34,
+			field:   "exercises[1]",
+			snippet: "      \"exercises\": [{\"slug\": \"two\"}, \"three\"]\n                                     ^",
+		},
+	}
+
+	for _, tt := range tests {
+		cfg := Config{}
+		err := json.Unmarshal([]byte(tt.src), &cfg)
+		if !assert.Error(t, err, tt.desc) {
+			continue
+		}
+
+		e := NewJSONError("config.json", []byte(tt.src), err)
+		assert.Equal(t, tt.line, e.Line, tt.desc)
+		assert.Equal(t, tt.column, e.Column, tt.desc)
+		assert.Equal(t, tt.field, e.Field, tt.desc)
+		assert.Equal(t, tt.snippet, e.Snippet, tt.desc)
+		assert.Equal(t, err, e.Unwrap(), tt.desc)
+	}
+}
+
+func TestJSONErrorInKeyWithUnmarshalJSON(t *testing.T) {
+	src := "{\n  \"files\": []\n}"
+	ec := ExerciseConfig{}
+	err := json.Unmarshal([]byte(src), &ec)
+
+	e := NewJSONError(".meta/config.json", []byte(src), err)
+	assert.Equal(t, "files", e.Field)
+	assert.Equal(t, 2, e.Line)
+	assert.Equal(t, 12, e.Column)
+}
+
+func TestJSONErrorMessage(t *testing.T) {
+	src := "{\n  \"maintainers\": [{\"github_username\": 1}]\n}"
+	mc := MaintainerConfig{}
+	err := json.Unmarshal([]byte(src), &mc)
+
+	e := NewJSONError("maintainers.json", []byte(src), err)
+	expected := "maintainers.json:2:39: maintainers[0].github_username: expected a string, found a number\n" +
+		"      \"maintainers\": [{\"github_username\": 1}]\n" +
+		"                                          ^"
+	assert.Equal(t, expected, e.Error())
+
+	src = `{"maintainers": [{"github_username": "one"}, 2]}`
+	err = json.Unmarshal([]byte(src), &mc)
+	e = NewJSONError("maintainers.json", []byte(src), err)
+	assert.Equal(t, "maintainers[1]", e.Field)
+	assert.Equal(t, 46, e.Column)
+
+	e = NewJSONError("maintainers.json", []byte(src), assert.AnError)
+	assert.Equal(t, "maintainers.json -- "+assert.AnError.Error(), e.Error())
+}
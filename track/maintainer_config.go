@@ -8,18 +8,57 @@ import (
 	"path/filepath"
 )
 
+// maintainerConfigKeyOrder is the canonical order of the keys in maintainers.json.
+var maintainerConfigKeyOrder = []string{
+	"docs_url",
+	"maintainers",
+}
+
+// maintainerKeyOrder is the canonical order of the keys of a maintainer in maintainers.json.
+var maintainerKeyOrder = []string{
+	"github_username",
+	"alumnus",
+	"show_on_website",
+	"name",
+	"link_text",
+	"link_url",
+	"avatar_url",
+	"bio",
+}
+
 // MaintainerConfig contains the list of current and previous maintainers.
 // The files is used both to manage the GitHub maintainer team, as well
 // as to configure the display values for each maintainer on the Exercism
 // website.
 type MaintainerConfig struct {
 	DocsURL     string       `json:"docs_url"`
-	Maintainers []Maintainer `json:"maintainers"`
+	Maintainers []Maintainer `json:"maintainers" schema:"required"`
+	// Extra holds the keys that configlet does not know about,
+	// so that they are kept when the config is formatted.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the maintainer config, keeping any unknown keys in Extra.
+func (mCfg *MaintainerConfig) UnmarshalJSON(data []byte) error {
+	type maintainerConfig MaintainerConfig
+	if err := json.Unmarshal(data, (*maintainerConfig)(mCfg)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, maintainerConfig{})
+	mCfg.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the maintainer config in the canonical key order,
+// followed by the keys in Extra.
+func (mCfg MaintainerConfig) MarshalJSON() ([]byte, error) {
+	type maintainerConfig MaintainerConfig
+	return marshalOrdered(maintainerConfig(mCfg), maintainerConfigKeyOrder, mCfg.Extra)
 }
 
 // Maintainer contains data about a track maintainer.
 type Maintainer struct {
-	Username      string  `json:"github_username"`
+	Username      string  `json:"github_username" schema:"required"`
 	Alumnus       bool    `json:"alumnus"`
 	ShowOnWebsite bool    `json:"show_on_website"`
 	Name          *string `json:"name"`
@@ -27,6 +66,27 @@ type Maintainer struct {
 	LinkURL       *string `json:"link_url"`
 	AvatarURL     *string `json:"avatar_url"`
 	Bio           *string `json:"bio"`
+	// Extra holds the keys that configlet does not know about,
+	// so that they are kept when the config is formatted.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the maintainer, keeping any unknown keys in Extra.
+func (m *Maintainer) UnmarshalJSON(data []byte) error {
+	type maintainer Maintainer
+	if err := json.Unmarshal(data, (*maintainer)(m)); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, maintainer{})
+	m.Extra = extra
+	return err
+}
+
+// MarshalJSON encodes the maintainer in the canonical key order,
+// followed by the keys in Extra.
+func (m Maintainer) MarshalJSON() ([]byte, error) {
+	type maintainer Maintainer
+	return marshalOrdered(maintainer(m), maintainerKeyOrder, m.Extra)
 }
 
 // NewMaintainerConfig reads the maintainer config file, if present.
@@ -42,20 +102,20 @@ func NewMaintainerConfig(path string) (MaintainerConfig, error) {
 	}
 	err = json.Unmarshal(bytes, &mc)
 	if err != nil {
-		return mc, fmt.Errorf("invalid config %s -- %s", path, err.Error())
+		return mc, fmt.Errorf("invalid config %s", NewJSONError(path, bytes, err))
 	}
 	return mc, nil
 }
 
 // LoadFromFile loads a config from file given the path to the file.
 func (mCfg *MaintainerConfig) LoadFromFile(path string) error {
-	file, err := os.Open(filepath.FromSlash(path))
+	bytes, err := ioutil.ReadFile(filepath.FromSlash(path))
 	if err != nil {
 		return err
 	}
 
-	if err := json.NewDecoder(file).Decode(mCfg); err != nil {
-		return err
+	if err := json.Unmarshal(bytes, mCfg); err != nil {
+		return fmt.Errorf("invalid config %s", NewJSONError(path, bytes, err))
 	}
 	return nil
 }
@@ -0,0 +1,137 @@
+package track
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// metadataKeyOrder is the canonical order of the keys in a metadata.yml file.
+var metadataKeyOrder = []string{
+	"blurb",
+	"title",
+	"source",
+	"source_url",
+}
+
+// Metadata is a metadata.yml file, either of a problem specification or
+// a track's override of it in an exercise's .meta directory.
+// It keeps every key, so that it can be formatted without losing any.
+type Metadata struct {
+	items yaml.MapSlice
+}
+
+// LoadFromFile loads the metadata from file given the path to the file.
+// Files with comments are rejected with a CommentError,
+// as the comments would be lost when formatting.
+func (m *Metadata) LoadFromFile(path string) error {
+	b, err := ioutil.ReadFile(filepath.FromSlash(path))
+	if err != nil {
+		return err
+	}
+
+	m.items = yaml.MapSlice{}
+	if err := yaml.Unmarshal(b, &m.items); err != nil {
+		return fmt.Errorf("invalid metadata %s -- %s", path, err.Error())
+	}
+
+	if line := commentLine(b, m.items); line > 0 {
+		return &CommentError{Path: path, Line: line}
+	}
+	return nil
+}
+
+// CommentError is returned when loading a metadata file with a comment.
+type CommentError struct {
+	Path string
+	// Line is the line of the first comment, starting at 1.
+	Line int
+}
+
+func (e *CommentError) Error() string {
+	return fmt.Sprintf("%s has a comment on line %d, which formatting would remove", e.Path, e.Line)
+}
+
+// commentLine returns the line of the first comment in the YAML data,
+// or 0 if it has none. A # that starts a line or follows a space may also
+// be part of a quoted string or a block scalar, so each one is checked by
+// removing it and the rest of its line: only a comment can be removed
+// without changing the items that the data holds.
+func commentLine(data []byte, items yaml.MapSlice) int {
+	lines := bytes.Split(data, []byte("\n"))
+	for n, line := range lines {
+		for i := range line {
+			if line[i] != '#' || (i > 0 && line[i-1] != ' ' && line[i-1] != '\t') {
+				continue
+			}
+
+			edited := make([][]byte, len(lines))
+			copy(edited, lines)
+			edited[n] = line[:i]
+			var other yaml.MapSlice
+			if err := yaml.Unmarshal(bytes.Join(edited, []byte("\n")), &other); err == nil && reflect.DeepEqual(items, other) {
+				return n + 1
+			}
+		}
+	}
+	return 0
+}
+
+// ToYAML marshals the metadata to normalized YAML. The keys are written in
+// the canonical order, followed by any others in alphabetical order,
+// and strings are double-quoted.
+func (m Metadata) ToYAML() ([]byte, error) {
+	rank := map[string]int{}
+	for i, key := range metadataKeyOrder {
+		rank[key] = i
+	}
+	items := make(yaml.MapSlice, len(m.items))
+	copy(items, m.items)
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := fmt.Sprint(items[i].Key), fmt.Sprint(items[j].Key)
+		ra, oka := rank[a]
+		rb, okb := rank[b]
+		switch {
+		case oka && okb:
+			return ra < rb
+		case oka || okb:
+			return oka
+		default:
+			return a < b
+		}
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	for _, item := range items {
+		s, ok := item.Value.(string)
+		if !ok {
+			b, err := yaml.Marshal(yaml.MapSlice{item})
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(b)
+			continue
+		}
+
+		key, err := yaml.Marshal(item.Key)
+		if err != nil {
+			return nil, err
+		}
+		// A JSON string is also a valid double-quoted YAML string.
+		var value bytes.Buffer
+		enc := json.NewEncoder(&value)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(s); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "%s: %s", bytes.TrimSpace(key), value.Bytes())
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,83 @@
+package track
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestMetadataToYAML(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metadata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "metadata.yml")
+	src := "source_url: http://example.com\ntags: [a, b]\ntitle: 'Say \"one\"'\nblurb: One.\n"
+	if err := ioutil.WriteFile(path, []byte(src), os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+
+	m := Metadata{}
+	if err := m.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := m.ToYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "---\nblurb: \"One.\"\ntitle: \"Say \\\"one\\\"\"\nsource_url: \"http://example.com\"\ntags:\n- a\n- b"
+	assert.Equal(t, expected, string(dst))
+
+	spec := ProblemSpecification{}
+	if err := yaml.Unmarshal(dst, &spec); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `Say "one"`, spec.Title)
+}
+
+func TestMetadataWithComments(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metadata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		desc string
+		src  string
+		line int
+	}{
+		{"comment line", "---\n# A comment.\nblurb: One.\n", 2},
+		{"indented comment line", "---\nblurb: One.\n  # A comment.\n", 3},
+		{"comment after a value", "---\nblurb: \"One #1.\" # A comment.\n", 2},
+		{"hash in a plain string", "---\nblurb: Learn C#.\n", 0},
+		{"hash in a quoted string", "---\nblurb: \"Learn # and more.\"\n", 0},
+		{"hash in a block scalar", "---\nblurb: |\n  # Heading\n  One.\n", 0},
+		{"hash in a folded scalar", "---\nblurb: >\n  One\n\n  # two.\n", 0},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(dir, "metadata.yml")
+		if err := ioutil.WriteFile(path, []byte(tt.src), os.FileMode(0644)); err != nil {
+			t.Fatal(err)
+		}
+
+		m := Metadata{}
+		err := m.LoadFromFile(path)
+		if tt.line == 0 {
+			assert.NoError(t, err, tt.desc)
+			continue
+		}
+		if cerr, ok := err.(*CommentError); assert.True(t, ok, tt.desc) {
+			assert.Equal(t, tt.line, cerr.Line, tt.desc)
+			assert.Contains(t, cerr.Error(), fmt.Sprintf("comment on line %d", tt.line), tt.desc)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package track
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+// PatternError is an invalid regular expression in a PatternGroup.
+type PatternError struct {
+	// Key is the config.json key of the pattern, such as solution_pattern.
+	Key string
+	// Pattern is the invalid regular expression.
+	Pattern string
+	// Column is the position in the pattern where the error was found,
+	// starting at 1, or 0 if it is not known.
+	Column int
+	// Err is the error from compiling the pattern.
+	Err error
+}
+
+func (e *PatternError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("%s %q is not a valid regular expression: %s", e.Key, e.Pattern, e.Err)
+	}
+	return fmt.Sprintf("%s %q is not a valid regular expression at column %d: %s", e.Key, e.Pattern, e.Column, e.Err)
+}
+
+// newPatternError describes the error from compiling the pattern,
+// finding where the offending part of the pattern is.
+func newPatternError(key, pattern string, err error) *PatternError {
+	e := &PatternError{Key: key, Pattern: pattern, Err: err}
+
+	if serr, ok := err.(*syntax.Error); ok {
+		// The position of the offending expression is not reported,
+		// but the expression is, and usually only occurs once.
+		if i := strings.Index(pattern, serr.Expr); serr.Expr != "" && i >= 0 {
+			e.Column = i + 1
+		}
+	}
+	return e
+}
+
+// Validate checks that each of the patterns is a valid regular expression.
+// The error lists every invalid pattern as a *PatternError.
+func (pg PatternGroup) Validate() error {
+	return pg.validate(true)
+}
+
+// validate checks the solution and test patterns, and the ignore pattern
+// if withIgnore is true.
+func (pg PatternGroup) validate(withIgnore bool) error {
+	keys := []string{"solution_pattern", "test_pattern", "ignore_pattern"}
+	if !withIgnore {
+		keys = keys[:2]
+	}
+	patterns := map[string]string{
+		"solution_pattern": pg.SolutionPattern,
+		"test_pattern":     pg.TestPattern,
+		"ignore_pattern":   pg.IgnorePattern,
+	}
+
+	var errs *multierror.Error
+	for _, key := range keys {
+		if _, err := regexp.Compile(patterns[key]); err != nil {
+			errs = multierror.Append(errs, newPatternError(key, patterns[key], err))
+		}
+	}
+	if errs != nil {
+		errs.ErrorFormat = joinErrors
+	}
+	return errs.ErrorOrNil()
+}
+
+// joinErrors lists the errors one per line.
+func joinErrors(errs []error) string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
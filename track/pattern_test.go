@@ -0,0 +1,66 @@
+package track
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternGroupValidate(t *testing.T) {
+	pg := PatternGroup{
+		SolutionPattern: "[Ee]xample",
+		TestPattern:     "(?i)test",
+		IgnorePattern:   "",
+	}
+	assert.NoError(t, pg.Validate())
+
+	pg.SolutionPattern = "example|[Ee"
+	pg.TestPattern = "test)"
+	err := pg.Validate()
+	if !assert.Error(t, err) {
+		return
+	}
+
+	errs := err.(*multierror.Error).Errors
+	if assert.Equal(t, 2, len(errs)) {
+		solution := errs[0].(*PatternError)
+		assert.Equal(t, "solution_pattern", solution.Key)
+		assert.Equal(t, "example|[Ee", solution.Pattern)
+		assert.Equal(t, 9, solution.Column)
+		assert.Contains(t, solution.Error(), `solution_pattern "example|[Ee" is not a valid regular expression at column 9`)
+
+		test := errs[1].(*PatternError)
+		assert.Equal(t, "test_pattern", test.Key)
+		assert.Contains(t, test.Error(), "test_pattern")
+	}
+}
+
+func TestNewConfigInvalidPattern(t *testing.T) {
+	dir, err := ioutil.TempDir("", "invalid-pattern")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	if err := ioutil.WriteFile(path, []byte(`{"test_pattern": "*_test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewConfig(path)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), path)
+		assert.Contains(t, err.Error(), `test_pattern "*_test" is not a valid regular expression at column 1`)
+	}
+}
+
+func TestNewConfigUnsupportedIgnorePattern(t *testing.T) {
+	// The ignore pattern may use syntax that Go does not support.
+	c, err := NewConfig("../fixtures/tree/config.json")
+	assert.NoError(t, err)
+	assert.Error(t, c.PatternGroup.Validate())
+}
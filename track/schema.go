@@ -0,0 +1,233 @@
+package track
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// schemaDraft is the version of JSON Schema that the schemas follow.
+const schemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema describing a configuration file. It is generated
+// from the Go type that the file is decoded into, so that the two stay in sync.
+// Fields tagged with `schema:"required"` are required, and keys that are not
+// fields of the type are not allowed.
+type Schema struct {
+	Draft                string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 interface{}        `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+// ConfigSchema returns the schema of a track's config.json.
+func ConfigSchema() *Schema {
+	s := newSchema(reflect.TypeOf(Config{}))
+	s.Draft = schemaDraft
+	s.Title = "config.json"
+	return s
+}
+
+// MaintainerConfigSchema returns the schema of a track's config/maintainers.json.
+func MaintainerConfigSchema() *Schema {
+	s := newSchema(reflect.TypeOf(MaintainerConfig{}))
+	s.Draft = schemaDraft
+	s.Title = "maintainers.json"
+	return s
+}
+
+// newSchema describes the JSON values that decode into the type.
+func newSchema(t reflect.Type) *Schema {
+	s := &Schema{}
+	switch t.Kind() {
+	case reflect.Ptr:
+		s = newSchema(t.Elem())
+		s.Type = append(s.types(), "null")
+	case reflect.String:
+		s.Type = "string"
+	case reflect.Bool:
+		s.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+	case reflect.Slice, reflect.Array:
+		// A missing list is written as null.
+		s.Type = []string{"array", "null"}
+		s.Items = newSchema(t.Elem())
+	case reflect.Map:
+		s.Type = "object"
+	case reflect.Struct:
+		s.Type = "object"
+		s.Properties = map[string]*Schema{}
+		addProperties(s, t)
+		additional := false
+		s.AdditionalProperties = &additional
+	}
+	return s
+}
+
+// addProperties adds the fields of the struct type to the object schema,
+// including those of embedded structs.
+func addProperties(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addProperties(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = newSchema(field.Type)
+		if field.Tag.Get("schema") == "required" {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// types returns the JSON types that the schema allows.
+func (s *Schema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	}
+	return nil
+}
+
+// ToJSON marshals the schema to indented JSON.
+func (s *Schema) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// SchemaError is a place where a file does not match its schema.
+type SchemaError struct {
+	// Field is the path of the field, such as exercises[12].difficulty,
+	// or empty for the file as a whole.
+	Field string
+	// Offset is the byte offset of the field's value in the file.
+	Offset int64
+	// Unknown is true if the field is not in the schema.
+	Unknown bool
+	// Message describes the problem.
+	Message string
+}
+
+// Validate checks the JSON data against the schema.
+// It returns an error if the data is not valid JSON.
+func (s *Schema) Validate(data []byte) ([]SchemaError, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	offsets := map[string]int64{}
+	newJSONScanner(data).scan(func(t jsonToken) bool {
+		if _, ok := offsets[t.path]; !ok {
+			offsets[t.path] = t.start
+		}
+		return true
+	})
+
+	errs := []SchemaError{}
+	s.validate(v, "", func(field string, unknown bool, msg string) {
+		errs = append(errs, SchemaError{Field: field, Offset: offsets[field], Unknown: unknown, Message: msg})
+	})
+	return errs, nil
+}
+
+// validate checks the value of the field against the schema,
+// calling report for each problem.
+func (s *Schema) validate(v interface{}, field string, report func(field string, unknown bool, msg string)) {
+	if types := s.types(); len(types) > 0 {
+		kind := jsonValueKind(v)
+		ok := false
+		for _, t := range types {
+			if t == kind || (t == "number" && kind == "integer") {
+				ok = true
+			}
+		}
+		if !ok {
+			expected := make([]string, len(types))
+			for i, t := range types {
+				expected[i] = withArticle(t)
+			}
+			report(field, false, fmt.Sprintf("expected %s, found %s", strings.Join(expected, " or "), withArticle(kind)))
+			return
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, key := range s.Required {
+			if _, ok := v[key]; !ok {
+				report(field, false, fmt.Sprintf("missing the required key %q", key))
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := joinField(field, key)
+			if prop, ok := s.Properties[key]; ok {
+				prop.validate(v[key], child, report)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				report(child, true, fmt.Sprintf("unknown key %q", key))
+			}
+		}
+	case []interface{}:
+		if s.Items == nil {
+			return
+		}
+		for i, item := range v {
+			s.Items.validate(item, fmt.Sprintf("%s[%d]", field, i), report)
+		}
+	}
+}
+
+// joinField returns the path of the key in the object at field.
+func joinField(field, key string) string {
+	if field == "" {
+		return key
+	}
+	return field + "." + key
+}
+
+// jsonValueKind returns the JSON type of a decoded value.
+func jsonValueKind(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
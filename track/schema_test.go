@@ -0,0 +1,71 @@
+package track
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishedSchemas(t *testing.T) {
+	tests := []struct {
+		path   string
+		schema *Schema
+	}{
+		{"config.json", ConfigSchema()},
+		{"maintainers.json", MaintainerConfigSchema()},
+	}
+
+	for _, tt := range tests {
+		published, err := ioutil.ReadFile(filepath.Join("..", "schemas", tt.path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		generated, err := tt.schema.ToJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, string(published), string(generated)+"\n", "schemas/%s should be regenerated with the schema command.", tt.path)
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	s := ConfigSchema()
+
+	assert.Equal(t, []string{"language", "exercises"}, s.Required)
+	assert.Equal(t, "string", s.Properties["solution_pattern"].Type, "should include the fields of embedded structs.")
+	assert.NotContains(t, s.Properties, "Extra")
+
+	exercise := s.Properties["exercises"].Items
+	assert.Equal(t, []string{"slug"}, exercise.Required)
+	assert.Equal(t, []string{"string", "null"}, exercise.Properties["unlocked_by"].Type)
+	assert.Equal(t, "integer", exercise.Properties["difficulty"].Type)
+}
+
+func TestSchemaValidate(t *testing.T) {
+	src := `{
+  "language": "Numbers",
+  "active": null,
+  "version": 3,
+  "exercises": [
+    {"slug": "one", "uuid": "1", "difficulty": 1.5},
+    {"uuid": "2", "status": "beta"}
+  ]
+}`
+	errs, err := ConfigSchema().Validate([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []SchemaError{
+		{Field: "active", Offset: 39, Message: "expected a boolean, found null"},
+		{Field: "exercises[0].difficulty", Offset: 125, Message: "expected an integer, found a number"},
+		{Field: "exercises[1]", Offset: 135, Message: `missing the required key "slug"`},
+		{Field: "exercises[1].status", Offset: 159, Unknown: true, Message: `unknown key "status"`},
+		{Field: "version", Offset: 58, Unknown: true, Message: `unknown key "version"`},
+	}, errs)
+
+	_, err = ConfigSchema().Validate([]byte(`{"language": }`))
+	assert.Error(t, err)
+}
@@ -15,8 +15,13 @@ type Track struct {
 	path             string
 }
 
+// Path returns the path of the track's directory.
+func (t Track) Path() string {
+	return t.path
+}
+
 // New loads a track.
-func New(path string) (Track, error) {
+func New(path string, opts ...Option) (Track, error) {
 	track := Track{
 		path: filepath.FromSlash(path),
 	}
@@ -55,7 +60,7 @@ func New(path string) (Track, error) {
 			}
 			fp := filepath.Join(dir, fn)
 
-			ex, err := NewExercise(fp, track.Config.PatternGroup)
+			ex, err := NewExercise(fp, track.Config.PatternGroup, opts...)
 			if err != nil {
 				return track, err
 			}